@@ -3,31 +3,44 @@ package fileHandler
 import (
 	"LemIn/errorHandler"
 	"bufio"
+	"io"
 	"os"
 )
 
 func ReadAll(fileName string) []string {
-	// Open the file
-	file, err := os.Open(fileName)
+	lines, err := ReadLines(fileName)
 	if err != nil {
 		errorHandler.CheckError(err, true)
 		return nil
 	}
+	return lines
+}
+
+// ReadLines is ReadAll returning the error instead of exiting
+func ReadLines(fileName string) ([]string, error) {
+	// Open the file
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
 
 	defer file.Close()
+	return ScanLines(file)
+}
 
+// ScanLines reads the lines of a farm from any reader, e.g. a request body
+func ScanLines(reader io.Reader) ([]string, error) {
 	// Read lines using a scanner
 	var lines []string
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(reader)
 	for scanner.Scan() {
 		lines = append(lines, scanner.Text())
 	}
 
 	// Check for scanner errors
 	if err := scanner.Err(); err != nil {
-		errorHandler.CheckError(err, true)
-		return nil
+		return nil, err
 	}
 
-	return lines
+	return lines, nil
 }
@@ -5,6 +5,6 @@ import (
 )
 
 func main() {
-	fileName := utils.ReadFromCommandLine()
-	utils.Lem_in(fileName)
+	options := utils.ReadFromCommandLine()
+	utils.Run(options)
 }
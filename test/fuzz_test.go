@@ -0,0 +1,190 @@
+package utils_test
+
+import (
+	"LemIn/utils"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// FuzzParseContent checks the parser behind CheckContent never panics and
+// either returns a farm that can be solved or an error telling the line.
+func FuzzParseContent(f *testing.F) {
+	files, _ := filepath.Glob("../examples/*.txt")
+	more, _ := filepath.Glob("*.txt")
+	for _, file := range append(files, more...) {
+		content, err := os.ReadFile(file)
+		if err == nil && len(content) < 4096 {
+			f.Add(string(content))
+		}
+	}
+	f.Add("1\n##start\n 0 0\n##end\nb 1 1\n-b\n")
+	f.Add("1\nx 0 0\n##end\nb 1 1\nx-b\n##start")
+	f.Add("1\n##start\n##end\na 0 0\nb 1 1\na-b\n")
+	f.Add("1\n#\n#\n##start\na 0 0\n##end\nb 1 1\n")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		lines := strings.Split(content, "\n")
+		numberOfAnts, rooms, tunnels, err := utils.ParseContent(lines)
+		if err != nil {
+			var parseError *utils.ParseError
+			if !errors.As(err, &parseError) {
+				t.Fatalf("Expected a *ParseError but got %T: %v", err, err)
+			}
+			if parseError.Line < 0 || parseError.Line > len(lines) {
+				t.Fatalf("Error line %d is outside of the %d lines", parseError.Line, len(lines))
+			}
+			return
+		}
+
+		if numberOfAnts < 1 {
+			t.Fatalf("Accepted %d ants", numberOfAnts)
+		}
+		starts, ends := 0, 0
+		names := make(map[string]bool)
+		for _, room := range rooms {
+			if room.Name == "" || strings.ContainsAny(room.Name, " ->") || strings.HasPrefix(room.Name, "L") || strings.HasPrefix(room.Name, "#") {
+				t.Fatalf("Accepted room name %q", room.Name)
+			}
+			if names[room.Name] {
+				t.Fatalf("Accepted duplicate room %q", room.Name)
+			}
+			names[room.Name] = true
+			if room.IsStart {
+				starts++
+			}
+			if room.IsEnd {
+				ends++
+			}
+		}
+		if starts != 1 || ends != 1 {
+			t.Fatalf("Accepted %d start and %d end rooms", starts, ends)
+		}
+		for _, tunnel := range tunnels {
+			if !names[tunnel.FromRoom.Name] || !names[tunnel.ToRoom.Name] {
+				t.Fatalf("Accepted tunnel %v to an unknown room", tunnel)
+			}
+		}
+	})
+}
+
+// randomFarm builds a farm of rooms with random tunnels, no two joining the
+// same rooms. A chain from start to end makes sure it can be solved.
+func randomFarm(random *rand.Rand) []string {
+	roomCount := 2 + random.Intn(9)
+	// Few ants leave paths of a group without ants, which the solvers must
+	// not count
+	maxAnts := 40
+	if random.Intn(2) == 0 {
+		maxAnts = 3
+	}
+	content := []string{fmt.Sprint(1 + random.Intn(maxAnts))}
+	for i := 0; i < roomCount; i++ {
+		switch i {
+		case 0:
+			content = append(content, "##start")
+		case roomCount - 1:
+			content = append(content, "##end")
+		}
+		content = append(content, fmt.Sprintf("r%d %d %d", i, random.Intn(100), random.Intn(100)))
+	}
+
+	joined := make(map[[2]int]bool)
+	addTunnel := func(from, to int) {
+		if from != to && !joined[[2]int{from, to}] {
+			joined[[2]int{from, to}], joined[[2]int{to, from}] = true, true
+			content = append(content, fmt.Sprintf("r%d-r%d", from, to))
+		}
+	}
+	chain := random.Perm(roomCount - 2)
+	previous := 0
+	for _, room := range chain[:random.Intn(len(chain)+1)] {
+		addTunnel(previous, room+1)
+		previous = room + 1
+	}
+	addTunnel(previous, roomCount-1)
+	for extra := random.Intn(2 * roomCount); extra > 0; extra-- {
+		addTunnel(random.Intn(roomCount), random.Intn(roomCount))
+	}
+	return content
+}
+
+func TestRandomFarms(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for farmIndex := 0; farmIndex < 1000; farmIndex++ {
+		content := randomFarm(random)
+		farm, err := utils.ParseFarm(content)
+		if err != nil {
+			t.Fatalf("%v\n%s", err, strings.Join(content, "\n"))
+		}
+
+		allPaths, err := utils.FindAllPaths(context.Background(), farm.Graph, farm.Start, farm.End, farm.Rooms)
+		if err != nil {
+			t.Fatalf("%v\n%s", err, strings.Join(content, "\n"))
+		}
+		sort.Sort(utils.PathSlice(allPaths))
+		groups := utils.RemoveSmallerGroups(utils.FilterNonIntersectingGroups(allPaths))
+		paths := utils.FindBestPathGroup(groups, farm.NumberOfAnts)
+		solutions := utils.MakeAntsQueue(paths, farm.NumberOfAnts)
+		turns := utils.SimulateAnts(solutions, paths, farm.Rooms, farm.NumberOfAnts, farm.End)
+
+		if err := utils.VerifyMoves(farm, turns); err != nil {
+			t.Errorf("%v\n%s", err, strings.Join(content, "\n"))
+		}
+		if predicted := utils.PredictTurns(paths, farm.NumberOfAnts); len(turns) != predicted {
+			t.Errorf("Expected the %d predicted turns but got %d on paths %v\n%s", predicted, len(turns), paths, strings.Join(content, "\n"))
+		}
+
+		// PredictTurns is what the solvers minimise, no group can beat the
+		// chosen one and the group search finds as few turns
+		for _, group := range groups {
+			groupPaths := make([][]string, len(group))
+			for i, path := range group {
+				for _, room := range path[1:] {
+					groupPaths[i] = append(groupPaths[i], room.Name)
+				}
+			}
+			if predicted := utils.PredictTurns(groupPaths, farm.NumberOfAnts); predicted < len(turns) {
+				t.Errorf("Expected no group faster than %d turns but %v takes %d\n%s", len(turns), groupPaths, predicted, strings.Join(content, "\n"))
+			}
+		}
+		result, err := utils.SolvePaths(context.Background(), farm)
+		if err != nil || len(result.Turns) != len(turns) {
+			t.Errorf("Expected SolvePaths to take %d turns but got %d (%v)\n%s", len(turns), len(result.Turns), err, strings.Join(content, "\n"))
+		}
+	}
+}
+
+func TestParseErrorLine(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		expectedLine int
+	}{
+		{name: "Ants", content: "#comment\nx\n##start\na 0 0\n##end\nb 1 1\na-b", expectedLine: 2},
+		{name: "Room after a comment", content: "1\n##start\na 0 0\n#comment\nc 1 2 3\n##end\nb 1 1\na-b", expectedLine: 5},
+		{name: "Start room", content: "1\n##start\n 0 0\n##end\nb 1 1\na-b", expectedLine: 3},
+		{name: "Tunnel", content: "1\n##start\na 0 0\n##end\nb 1 1\na-b\na-x", expectedLine: 7},
+		{name: "Duplicate", content: "1\n##start\na 0 0\n##end\nb 1 1\nc 2 2\nb 3 3\na-b", expectedLine: 7},
+		{name: "Second start", content: "1\n##start\na 0 0\n##start\nb 1 1\na-b", expectedLine: 4},
+		{name: "No end room", content: "1\n##start\na 0 0\nb 1 1\nc 2 2\na-b", expectedLine: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, _, err := utils.ParseContent(strings.Split(test.content, "\n"))
+			var parseError *utils.ParseError
+			if !errors.As(err, &parseError) {
+				t.Fatalf("Expected a *ParseError but got %v", err)
+			}
+			if parseError.Line != test.expectedLine {
+				t.Errorf("Expected line %d but got %d (%v)", test.expectedLine, parseError.Line, err)
+			}
+		})
+	}
+}
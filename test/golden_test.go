@@ -0,0 +1,127 @@
+package utils_test
+
+import (
+	"LemIn/errorHandler"
+	"LemIn/utils"
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// TestGolden runs lem-in on every map of examples/ and test/ and compares the
+// output with testdata/<dir>_<map>.golden. The first line of a golden file is
+// the number of turns, or "error", the rest is the output without colors.
+// Solved maps are also checked with VerifyMoves. Run with -update to rewrite
+// the golden files after a change of output, adding a map needs no Go code.
+func TestGolden(t *testing.T) {
+	var files []string
+	for _, pattern := range []string{"../examples/*.txt", "*.txt"} {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, matches...)
+	}
+
+	for _, file := range files {
+		dir := filepath.Base(filepath.Dir(file))
+		if dir == "." {
+			dir = "test"
+		}
+		name := dir + "_" + strings.TrimSuffix(filepath.Base(file), ".txt")
+		t.Run(name, func(t *testing.T) {
+			options := utils.Options{FileName: file, Jobs: 1}
+			if slowMaps[filepath.Base(file)] {
+				options.Paths = utils.AdaptivePaths
+			}
+			output, failed := runLemIn(options)
+			header := "error"
+			if !failed {
+				turns, err := verifyOutput(output)
+				if err != nil {
+					t.Errorf("Invalid solution: %v", err)
+				}
+				header = strconv.Itoa(turns)
+			}
+			got := header + "\n" + output
+
+			goldenFile := filepath.Join("testdata", name+".golden")
+			if *update {
+				if err := os.WriteFile(goldenFile, []byte(got), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(goldenFile)
+			if err != nil {
+				t.Fatalf("%v, run go test ./test -run TestGolden -update to create it", err)
+			}
+			if got != string(want) {
+				wantHeader, _, _ := strings.Cut(string(want), "\n")
+				t.Errorf("Output differs from %s (turns %s, expected %s).\nGot:\n%s\nExpected:\n%s", goldenFile, header, wantHeader, got, want)
+			}
+		})
+	}
+}
+
+// runLemIn runs lem-in like the command line and returns what it prints
+// without colors, and if it stopped on an error.
+func runLemIn(options utils.Options) (string, bool) {
+	failed := false
+	errorHandler.ExitFunc = func(code int) {
+		failed = true
+	}
+	defer func() {
+		errorHandler.ExitFunc = os.Exit
+	}()
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	flags := log.Flags()
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(flags)
+	}()
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		buf.ReadFrom(r)
+		done <- buf.String()
+	}()
+	utils.Run(options)
+	w.Close()
+	os.Stdout = oldStdout
+
+	return StripANSI(<-done + logs.String()), failed
+}
+
+// verifyOutput checks the moves printed after the farm and returns the
+// number of turns
+func verifyOutput(output string) (int, error) {
+	farmPart, movesPart, found := strings.Cut(output, "\n\n")
+	if !found {
+		return 0, fmt.Errorf("no empty line between the farm and the moves")
+	}
+	farm, err := utils.ParseFarm(strings.Split(farmPart, "\n"))
+	if err != nil {
+		return 0, err
+	}
+	turns, err := utils.ParseTranscript(strings.Split(movesPart, "\n"))
+	if err != nil {
+		return 0, err
+	}
+	return len(turns), utils.VerifyMoves(farm, turns)
+}
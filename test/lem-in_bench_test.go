@@ -0,0 +1,144 @@
+package utils_test
+
+import (
+	"LemIn/fileHandler"
+	"LemIn/utils"
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// slowMaps can not be solved by enumerating every path in benchmark time
+var slowMaps = map[string]bool{
+	"example08.txt": true,
+}
+
+type benchMap struct {
+	name        string
+	fileContent []string
+}
+
+func benchMaps(b *testing.B) []benchMap {
+	files, err := filepath.Glob("../examples/*.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var maps []benchMap
+	for _, file := range files {
+		fileContent, err := fileHandler.ReadLines(file)
+		if err != nil {
+			b.Fatal(err)
+		}
+		maps = append(maps, benchMap{name: filepath.Base(file), fileContent: fileContent})
+	}
+	for _, size := range []struct{ corridors, length, ants int }{
+		{6, 50, 1000},
+		{10, 100, 5000},
+	} {
+		maps = append(maps, benchMap{
+			name:        fmt.Sprint("generated-", size.corridors, "x", size.length, "-", size.ants),
+			fileContent: utils.GenerateFarm(size.corridors, size.length, size.ants),
+		})
+	}
+	return maps
+}
+
+// benchFarms returns the maps that parse and have a path
+func benchFarms(b *testing.B) []benchMap {
+	var maps []benchMap
+	for _, benchMap := range benchMaps(b) {
+		if slowMaps[benchMap.name] {
+			continue
+		}
+		farm, err := utils.ParseFarm(benchMap.fileContent)
+		if err != nil {
+			continue
+		}
+		if _, err := utils.FindAllPaths(context.Background(), farm.Graph, farm.Start, farm.End, farm.Rooms); err != nil {
+			continue
+		}
+		maps = append(maps, benchMap)
+	}
+	return maps
+}
+
+func BenchmarkParseFarm(b *testing.B) {
+	for _, benchMap := range benchMaps(b) {
+		b.Run(benchMap.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				utils.ParseFarm(benchMap.fileContent)
+			}
+		})
+	}
+}
+
+func BenchmarkFindPaths(b *testing.B) {
+	for _, benchMap := range benchFarms(b) {
+		farm, _ := utils.ParseFarm(benchMap.fileContent)
+		b.Run(benchMap.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				allPaths, _ := utils.FindAllPaths(context.Background(), farm.Graph, farm.Start, farm.End, farm.Rooms)
+				sort.Sort(utils.PathSlice(allPaths))
+				utils.SearchBestPathGroup(context.Background(), allPaths, farm.NumberOfAnts, 1)
+			}
+		})
+	}
+}
+
+func BenchmarkKShortestPaths(b *testing.B) {
+	for _, benchMap := range benchMaps(b) {
+		farm, err := utils.ParseFarm(benchMap.fileContent)
+		if err != nil {
+			continue
+		}
+		k := utils.AdaptivePathLimit(farm)
+		b.Run(benchMap.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				utils.KShortestPaths(context.Background(), farm.Graph, farm.Start, farm.End, farm.Rooms, k)
+			}
+		})
+	}
+}
+
+func BenchmarkSimulateAnts(b *testing.B) {
+	for _, benchMap := range benchFarms(b) {
+		farm, _ := utils.ParseFarm(benchMap.fileContent)
+		result, _ := utils.SolvePaths(context.Background(), farm)
+		b.Run(benchMap.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				solutions := utils.MakeAntsQueue(result.Paths, farm.NumberOfAnts)
+				utils.SimulateAnts(solutions, result.Paths, farm.Rooms, farm.NumberOfAnts, farm.End)
+			}
+		})
+	}
+}
+
+// BenchmarkIncrementalSolver removes a tunnel and puts it back between solves
+func BenchmarkIncrementalSolver(b *testing.B) {
+	for _, benchMap := range benchMaps(b) {
+		farm, err := utils.ParseFarm(benchMap.fileContent)
+		if err != nil || len(farm.Tunnels) == 0 {
+			continue
+		}
+		solver := utils.NewIncrementalSolver(farm)
+		if _, err := solver.Solve(context.Background()); err != nil {
+			continue
+		}
+		tunnel := farm.Tunnels[0]
+		b.Run(benchMap.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				solver.RemoveTunnel(tunnel.FromRoom.Name, tunnel.ToRoom.Name)
+				solver.Solve(context.Background())
+				solver.AddTunnel(tunnel.FromRoom.Name, tunnel.ToRoom.Name, tunnel.Directed)
+				solver.Solve(context.Background())
+			}
+		})
+	}
+}
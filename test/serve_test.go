@@ -0,0 +1,150 @@
+package utils_test
+
+import (
+	"LemIn/utils"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const serveFarm = "2\n##start\ns 0 0\na 1 0\n##end\nt 2 0\ns-a\na-t\n"
+
+func TestServe(t *testing.T) {
+	server := httptest.NewServer(utils.NewServeHandler(utils.Options{Jobs: 1, Timeout: time.Second, MaxAnts: 1000, MaxRooms: 100, MaxTurns: 1000}))
+	defer server.Close()
+
+	post := func(path, contentType, body string) (int, map[string]any) {
+		response, err := http.Post(server.URL+path, contentType, strings.NewReader(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer response.Body.Close()
+		var decoded map[string]any
+		if err := json.NewDecoder(response.Body).Decode(&decoded); err != nil {
+			t.Fatal(err)
+		}
+		return response.StatusCode, decoded
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		contentType    string
+		body           string
+		expectedStatus int
+		expected       map[string]any
+	}{
+		{
+			name: "Solve text", path: "/solve", contentType: "text/plain", body: serveFarm,
+			expectedStatus: http.StatusOK,
+			expected:       map[string]any{"ants": 2.0, "turns": []any{[]any{"L1-a"}, []any{"L1-t", "L2-a"}, []any{"L2-t"}}, "timed_out": false},
+		},
+		{
+			name: "Solve JSON with the exact solver", path: "/solve", contentType: "application/json",
+			body:           `{"farm": "2\n##start\ns 0 0\na 1 0\n##end\nt 2 0\ns-a\na-t", "solver": "exact"}`,
+			expectedStatus: http.StatusOK,
+			expected:       map[string]any{"ants": 2.0, "paths": nil},
+		},
+		{
+			name: "Solve invalid farm", path: "/solve", contentType: "text/plain", body: "0\n",
+			expectedStatus: http.StatusBadRequest,
+			expected:       map[string]any{"error": "ERROR: invalid data format"},
+		},
+		{
+			name: "Unknown solver", path: "/solve", contentType: "application/json", body: `{"farm": "1", "solver": "nope"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Too many ants", path: "/solve", contentType: "application/json",
+			body:           `{"farm": "20000000\n##start\ns 0 0\na 1 0\n##end\nt 2 0\ns-a\na-t", "solver": "exact"}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expected:       map[string]any{"error": "ERROR: the farm has 20000000 ants, at most 1000 are solved"},
+		},
+		{
+			name: "Too many rooms", path: "/solve", contentType: "text/plain", body: manyRoomsFarm(101),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expected:       map[string]any{"error": "ERROR: the farm has 101 rooms, at most 100 are solved"},
+		},
+		{
+			name: "Too many turns", path: "/solve", contentType: "text/plain", body: "1000" + strings.TrimPrefix(serveFarm, "2"),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expected:       map[string]any{"error": "ERROR: the farm needs at least 1001 turns, at most 1000 are solved"},
+		},
+		{
+			name: "Validate", path: "/validate", contentType: "text/plain", body: serveFarm,
+			expectedStatus: http.StatusOK,
+			expected:       map[string]any{"valid": true, "ants": 2.0, "rooms": 3.0, "tunnels": 2.0},
+		},
+		{
+			name: "Validate invalid farm", path: "/validate", contentType: "text/plain", body: "0\n",
+			expectedStatus: http.StatusOK,
+			expected:       map[string]any{"valid": false, "error": "ERROR: invalid data format"},
+		},
+		{
+			name: "Verify lem-in output", path: "/verify", contentType: "text/plain",
+			body:           serveFarm + "\nturn 1: L1-a \nturn 2: \033[43mL1-t\033[0m L2-a \nturn 3: \033[43mL2-t\033[0m \n",
+			expectedStatus: http.StatusOK,
+			expected:       map[string]any{"valid": true, "turns": 3.0},
+		},
+		{
+			name: "Verify illegal moves", path: "/verify", contentType: "application/json",
+			body:           `{"farm": "2\n##start\ns 0 0\na 1 0\n##end\nt 2 0\ns-a\na-t", "moves": "L1-a L2-a\nL1-t L2-t"}`,
+			expectedStatus: http.StatusOK,
+			expected:       map[string]any{"valid": false, "error": "ERROR: invalid moves, turn 1: tunnel s-a is used more than once"},
+		},
+		{
+			name: "Too large", path: "/validate", contentType: "text/plain", body: strings.Repeat("#\n", 3<<20),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, decoded := post(test.path, test.contentType, test.body)
+			if status != test.expectedStatus {
+				t.Errorf("Expected status %v but got %v (%v)", test.expectedStatus, status, decoded)
+			}
+			for key, value := range test.expected {
+				if got, _ := json.Marshal(decoded[key]); string(got) != mustJSON(t, value) {
+					t.Errorf("Expected %s to be %s but got %s", key, mustJSON(t, value), got)
+				}
+			}
+		})
+	}
+
+	response, err := http.Get(server.URL + "/solve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("Expected GET /solve to be refused but got %v", response.StatusCode)
+	}
+}
+
+// manyRoomsFarm returns a farm of one ant and a chain of rooms
+func manyRoomsFarm(rooms int) string {
+	lines := []string{"1", "##start", "r0 0 0"}
+	for i := 1; i < rooms; i++ {
+		if i == rooms-1 {
+			lines = append(lines, "##end")
+		}
+		lines = append(lines, fmt.Sprintf("r%d %d 0", i, i))
+	}
+	for i := 1; i < rooms; i++ {
+		lines = append(lines, fmt.Sprintf("r%d-r%d", i-1, i))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func mustJSON(t *testing.T, value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(encoded)
+}
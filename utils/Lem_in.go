@@ -1,56 +1,154 @@
 package utils
 
 import (
+	"LemIn/errorHandler"
 	"LemIn/fileHandler"
+	"errors"
 	"fmt"
-	"sort"
+	"log"
 )
 
 type PathSlice [][]Room
 
 func (p PathSlice) Len() int           { return len(p) }
 func (p PathSlice) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
-func (p PathSlice) Less(i, j int) bool { return len(p[i]) < len(p[j]) }
+func (p PathSlice) Less(i, j int) bool { return ComparePaths(p[i], p[j]) < 0 }
 
 func Lem_in(fileName string) {
-	fileContent := fileHandler.ReadAll(fileName)
+	Run(Options{FileName: fileName})
+}
 
-	numberOfAnts, rooms, tunnels := CheckContent(fileContent)
-	if numberOfAnts == -1 || rooms == nil || tunnels == nil {
+func Run(options Options) {
+	switch options.Command {
+	case "bench":
+		Bench(options)
+		return
+	case "batch":
+		Batch(options)
+		return
+	case "inspect":
+		Inspect(options)
+		return
+	case "curve":
+		Curve(options)
+		return
+	case "criticality":
+		CriticalityReport(options)
+		return
+	case "serve":
+		Serve(options)
 		return
 	}
-	graph := CreateGraph(tunnels)
-	graph.Vertices = len(rooms)
 
-	_, startRoom := FindStart(rooms)
-	_, endRoom := FindEnd(rooms)
+	fileContent := fileHandler.ReadAll(options.FileName)
 
-	// Step 1: Extract all paths
-	allPaths := ExtractAllPaths(graph, startRoom, endRoom, rooms)
-	if allPaths == nil {
+	farm, err := ParseFarm(fileContent)
+	if err != nil {
+		errorHandler.CheckError(err, true)
 		return
 	}
 
-	sort.Sort(PathSlice(allPaths))
+	ctx, cancel := withTimeout(options.Timeout)
+	defer cancel()
 
-	// Step 2: Filter non-intersecting groups
-	nonIntersectingGroups := FilterNonIntersectingGroups(allPaths)
+	solve, lowerBound := SolvePaths, LowerBound
+	if options.Geometric {
+		solve, lowerBound = SolveGeometric, GeometricLowerBound
+	}
+	if options.Geometric && options.Alternatives > 0 {
+		errorHandler.CheckError(errors.New("ERROR: --alternatives does not work with --geometric yet"), true)
+		return
+	}
+	if options.Geometric && options.Explain > 0 {
+		errorHandler.CheckError(errors.New("ERROR: --explain does not work with --geometric yet"), true)
+		return
+	}
+	if options.Geometric && (options.Usage || options.Report != "") {
+		errorHandler.CheckError(errors.New("ERROR: --usage and --report do not work with --geometric yet"), true)
+		return
+	}
+	result, err := solve(ctx, farm, WithJobs(options.Jobs), WithPathLimit(options.Paths), WithSeed(options.Seed), WithAlternatives(options.Alternatives), WithObjective(options.Objective))
+	if ctx.Err() != nil {
+		log.Println("Warning: timeout reached, using the best solution found so far")
+	}
+	if result.AlternativesCut {
+		log.Println("Warning: timeout reached, the alternatives only come from the groups of paths ranked so far")
+	}
+	if err != nil {
+		errorHandler.CheckError(err, true)
+		return
+	}
+	stats := MakeStats(result.Turns, farm.End, lowerBound(farm.Graph, farm.Rooms, farm.Start, farm.End, farm.NumberOfAnts), result.PrunedRooms)
+	if options.Objective != nil {
+		stats.Objective = options.Objective.String()
+	}
 
-	// Step 3: Remove smaller groups with in common members
-	filteredGroups := RemoveSmallerGroups(nonIntersectingGroups)
+	var explanation *Explanation
+	if options.Explain > 0 {
+		explained, err := ExplainFarm(ctx, farm, options.Explain, WithPathLimit(options.Paths), WithSeed(options.Seed), WithObjective(options.Objective))
+		if err != nil {
+			errorHandler.CheckError(err, true)
+			return
+		}
+		explanation = &explained
+	}
 
-	// Step 4: Find best group of paths
-	bestPathGroupNames := FindBestPathGroup(filteredGroups, numberOfAnts)
+	var traces []AntTrace
+	if len(options.TraceAnts) > 0 {
+		if options.Geometric {
+			errorHandler.CheckError(errors.New("ERROR: --trace-ant does not work with --geometric yet"), true)
+			return
+		}
+		antIds, err := ExpandAntIds(options.TraceAnts, farm.NumberOfAnts)
+		if err == nil {
+			traces, err = TraceAnts(farm, result.Paths, antIds)
+		}
+		if err != nil {
+			errorHandler.CheckError(err, true)
+			return
+		}
+	}
 
-	// Step 5: Assign ants to group of paths named solution
-	solutions := MakeAntsQueue(bestPathGroupNames, numberOfAnts)
+	var usage *UsageReport
+	if options.Usage || options.Report != "" {
+		report := MakeUsageReport(farm, result.Paths)
+		usage = &report
+		if options.Report != "" {
+			errorHandler.CheckError(WriteUsageReport(options.Report, report), true)
+		}
+	}
 
-	// Step 6: Print file contents
+	if options.JSON {
+		output := MakeJSONOutput(farm.NumberOfAnts, result.Paths, result.Turns, stats)
+		output.Explanation = explanation
+		output.Traces = traces
+		if options.Usage {
+			output.Usage = usage
+		}
+		for _, alternative := range result.Alternatives {
+			alternativeStats := MakeStats(alternative.Turns, farm.End, stats.LowerBound, stats.PrunedRooms)
+			output.Alternatives = append(output.Alternatives, MakeJSONOutput(farm.NumberOfAnts, alternative.Paths, alternative.Turns, alternativeStats))
+		}
+		PrintJSON(output)
+		return
+	}
+
+	// Print file contents and the moves
 	for i := 0; i < len(fileContent); i++ {
 		fmt.Println(fileContent[i])
 	}
 	fmt.Println()
+	PrintMoves(result.Turns, farm.End)
 
-	// Step 7: Move ants in solution
-	MoveAnts(solutions, bestPathGroupNames, rooms, numberOfAnts, endRoom)
+	if options.Stats {
+		fmt.Println(stats)
+	}
+	PrintAlternatives(result.Alternatives, farm.End)
+	PrintTraces(traces, len(result.Paths))
+	if options.Usage {
+		PrintUsageReport(*usage)
+	}
+	if explanation != nil {
+		PrintExplanation(*explanation)
+	}
 }
@@ -11,8 +11,18 @@ func MakeAntsQueue(paths [][]string, numberOfAnts int) []Solution {
 
 	pathIndex := 0
 
+	// How many ants each path gets, the split FindBestPathGroup timed
+	pathLengths := make([]int, numberOfPaths)
+	for i, path := range paths {
+		pathLengths[i] = len(path)
+	}
+	var shares []int
+	if numberOfPaths > 0 {
+		shares = assignAntsToPaths(pathLengths, numberOfAnts)
+	}
+
 	for antsIndex := 0; antsIndex < numberOfAnts; antsIndex++ {
-		pathIndex = findSuitablePath(paths, solutions)
+		pathIndex = findSuitablePath(paths, solutions, shares)
 
 		ants[antsIndex].PathIndex = pathIndex
 		solutions[pathIndex].Ants = append(solutions[pathIndex].Ants, ants[antsIndex])
@@ -21,25 +31,20 @@ func MakeAntsQueue(paths [][]string, numberOfAnts int) []Solution {
 	return solutions
 }
 
-func findSuitablePath(paths [][]string, solutions []Solution) int {
-	cntr := 0
-	for i := 0; cntr < len(solutions); i++ {
-		cntr++
-		if i != len(solutions)-1 {
-			if len(paths[i])+len(solutions[i].Ants) <= len(paths[i+1])+len(solutions[i+1].Ants) {
-				return i
-			} else {
-				continue
-			}
-		} else {
-			if len(paths[i])+len(solutions[i].Ants) <= len(paths[0])+len(solutions[0].Ants) {
-				return i
-			} else {
-				i = -1
-			}
+// findSuitablePath returns the path with the smallest length plus ants among
+// the ones that did not get their share yet. Ties go to the first path, paths
+// come in the order of ComparePaths.
+func findSuitablePath(paths [][]string, solutions []Solution, shares []int) int {
+	best := -1
+	for i := range solutions {
+		if len(solutions[i].Ants) >= shares[i] {
+			continue
+		}
+		if best == -1 || len(paths[i])+len(solutions[i].Ants) < len(paths[best])+len(solutions[best].Ants) {
+			best = i
 		}
 	}
-	return 0
+	return best
 }
 
 func initAnts(numberOfAnts int, ants []Ant) {
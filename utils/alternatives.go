@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// WithAlternatives makes SolvePaths also return the n best groups of paths
+// after the chosen one, with their moves
+func WithAlternatives(n int) SolveOption {
+	return func(config *SolveConfig) {
+		config.Alternatives = n
+	}
+}
+
+// findAlternatives ranks the groups of FilterNonIntersectingGroups by the
+// turns of their simulation, then like the group search with objective, then
+// by the total length of the paths given ants.
+// Paths getting no ant are left out, so two alternatives never move the ants
+// the same way, and the chosen group is skipped. Every group is kept in memory
+// like with ExplainFarm. When ctx is done the alternatives come from the groups
+// found so far and the returned bool is true.
+func findAlternatives(ctx context.Context, farm Farm, allPaths [][]Room, chosen [][]string, n int, objective Objective) ([]Result, bool) {
+	groups := RemoveSmallerGroupsContext(ctx, FilterNonIntersectingGroupsContext(ctx, allPaths))
+
+	type alternative struct {
+		paths  [][]string
+		turns  int
+		score  GroupScore
+		length int
+	}
+	seen := map[string]bool{usedPathsKey(chosen, farm.NumberOfAnts): true}
+	var alternatives []alternative
+	candidates, objective := rankGroups(allPaths, groups, farm.NumberOfAnts, objective)
+	for _, candidate := range candidates {
+		if ctx.Err() != nil {
+			break
+		}
+		var usedPaths [][]string
+		length := 0
+		for i, path := range candidate.Paths {
+			if candidate.Ants[i] > 0 {
+				usedPaths = append(usedPaths, path)
+				length += candidate.Lengths[i]
+			}
+		}
+		key := usedPathsKey(usedPaths, farm.NumberOfAnts)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		simulation := NewSimulation(MakeAntsQueue(usedPaths, farm.NumberOfAnts), usedPaths, farm.Start, farm.End)
+		alternatives = append(alternatives, alternative{paths: usedPaths, turns: simulation.Turns(), score: candidate.score, length: length})
+	}
+
+	// Groups come in the order of the group search, it breaks the last ties
+	sort.SliceStable(alternatives, func(i, j int) bool {
+		if alternatives[i].turns != alternatives[j].turns {
+			return alternatives[i].turns < alternatives[j].turns
+		}
+		if order := objective.compare(alternatives[i].score, alternatives[j].score); order != 0 {
+			return order < 0
+		}
+		return alternatives[i].length < alternatives[j].length
+	})
+	if len(alternatives) > n {
+		alternatives = alternatives[:n]
+	}
+
+	results := make([]Result, len(alternatives))
+	for i, alternative := range alternatives {
+		solutions := MakeAntsQueue(alternative.paths, farm.NumberOfAnts)
+		turns := SimulateAnts(solutions, alternative.paths, farm.Rooms, farm.NumberOfAnts, farm.End)
+		results[i] = Result{Paths: alternative.paths, Turns: turns}
+	}
+	return results, ctx.Err() != nil
+}
+
+// usedPathsKey names the paths of a group that get ants
+func usedPathsKey(paths [][]string, ants int) string {
+	if len(paths) == 0 {
+		return ""
+	}
+	lengths := make([]int, len(paths))
+	for i, path := range paths {
+		lengths[i] = len(path)
+	}
+	var key []string
+	for i, share := range assignAntsToPaths(lengths, ants) {
+		if share > 0 {
+			key = append(key, strings.Join(paths[i], " "))
+		}
+	}
+	return strings.Join(key, "|")
+}
+
+func PrintAlternatives(alternatives []Result, end Room) {
+	for i, alternative := range alternatives {
+		tunnels := 0
+		for _, path := range alternative.Paths {
+			tunnels += len(path)
+		}
+		fmt.Printf("\nalternative %d: %d turns, %d tunnels in %d paths\n", i+1, len(alternative.Turns), tunnels, len(alternative.Paths))
+		for j, path := range alternative.Paths {
+			fmt.Printf("   path %d: %s\n", j+1, strings.Join(path, " "))
+		}
+		PrintMoves(alternative.Turns, end)
+	}
+}
@@ -0,0 +1,200 @@
+package utils
+
+import (
+	"LemIn/errorHandler"
+	"LemIn/fileHandler"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// BatchResult is the outcome of solving one file of a batch
+type BatchResult struct {
+	File       string        `json:"file"`
+	Valid      bool          `json:"valid"`
+	Error      string        `json:"error,omitempty"`
+	ErrorKind  string        `json:"error_kind,omitempty"`
+	Rooms      int           `json:"rooms"`
+	Ants       int           `json:"ants"`
+	Turns      int           `json:"turns"`
+	Time       time.Duration `json:"time_ns"`
+	TimedOut   bool          `json:"timed_out"`
+	Expected   string        `json:"expected,omitempty"` // max turns or "error", from the .expected file
+	Regression bool          `json:"regression"`
+}
+
+// Batch solves every map of a directory in parallel, prints a summary table
+// and writes a CSV or JSON report when asked. With --expect a map whose
+// example.expected file holds a number must not need more turns than it, and
+// one holding "error" must be rejected.
+func Batch(options Options) {
+	files, err := filepath.Glob(filepath.Join(options.FileName, "*.txt"))
+	errorHandler.CheckError(err, true)
+	if len(files) == 0 {
+		errorHandler.CheckError(errors.New("ERROR: no map found in "+options.FileName), true)
+		return
+	}
+
+	results := SolveBatch(files, options)
+	PrintBatch(results)
+	if options.Report != "" {
+		errorHandler.CheckError(WriteBatchReport(options.Report, results), true)
+	}
+
+	regressions := 0
+	for _, result := range results {
+		if result.Regression {
+			regressions++
+		}
+	}
+	if regressions > 0 {
+		errorHandler.CheckError(fmt.Errorf("%d map(s) did worse than expected", regressions), true)
+	}
+}
+
+// SolveBatch solves the files on options.Workers goroutines and returns the
+// results in the order of files.
+func SolveBatch(files []string, options Options) []BatchResult {
+	results := make([]BatchResult, len(files))
+	workers := max(1, options.Workers)
+	fileIndexes := make(chan int)
+	var wait sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			for i := range fileIndexes {
+				results[i] = solveBatchFile(files[i], options)
+			}
+		}()
+	}
+	for i := range files {
+		fileIndexes <- i
+	}
+	close(fileIndexes)
+	wait.Wait()
+	return results
+}
+
+func solveBatchFile(file string, options Options) BatchResult {
+	result := BatchResult{File: filepath.Base(file)}
+	startTime := time.Now()
+	farm, err := ReadFarm(file)
+	if err == nil {
+		result.Rooms = len(farm.Rooms)
+		result.Ants = farm.NumberOfAnts
+		ctx, cancel := withTimeout(options.Timeout)
+		var solution Result
+		solution, err = SolvePaths(ctx, farm, WithJobs(options.Jobs), WithPathLimit(options.Paths))
+		result.TimedOut = ctx.Err() != nil
+		cancel()
+		result.Turns = len(solution.Turns)
+	}
+	result.Time = time.Since(startTime)
+	result.Valid = err == nil
+	if err != nil {
+		result.Error = err.Error()
+		result.ErrorKind = errorKind(err)
+	}
+
+	if options.Expect {
+		expected, readErr := fileHandler.ReadLines(strings.TrimSuffix(file, filepath.Ext(file)) + ".expected")
+		if readErr == nil && len(expected) > 0 {
+			result.Expected = strings.TrimSpace(expected[0])
+			result.Regression = isRegression(result)
+		}
+	}
+	return result
+}
+
+// errorKind shortens "ERROR: invalid data format, no start room found" to
+// "no start room found"
+func errorKind(err error) string {
+	kind := strings.TrimPrefix(err.Error(), "ERROR: ")
+	if _, detail, found := strings.Cut(kind, ", "); found {
+		return detail
+	}
+	return kind
+}
+
+func isRegression(result BatchResult) bool {
+	if result.Expected == "error" {
+		return result.Valid
+	}
+	maxTurns, err := strconv.Atoi(result.Expected)
+	if err != nil {
+		return true
+	}
+	return !result.Valid || result.Turns > maxTurns
+}
+
+func PrintBatch(results []BatchResult) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "FILE\tSTATUS\tROOMS\tANTS\tTURNS\tTIME\tEXPECTED")
+	valid := 0
+	for _, result := range results {
+		status, rooms, ants, turns := "ok", fmt.Sprint(result.Rooms), fmt.Sprint(result.Ants), fmt.Sprint(result.Turns)
+		if result.Valid {
+			valid++
+			if result.TimedOut {
+				status = "ok (timeout)"
+			}
+		} else {
+			status = "error: " + result.ErrorKind
+			turns = "-"
+			if result.Rooms == 0 {
+				rooms, ants = "-", "-"
+			}
+		}
+		expected := result.Expected
+		if result.Regression {
+			expected += " REGRESSION"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%v\t%s\n", result.File, status, rooms, ants, turns, result.Time.Round(time.Microsecond), expected)
+	}
+	writer.Flush()
+	fmt.Printf("%d maps, %d solved, %d rejected\n", len(results), valid, len(results)-valid)
+}
+
+// WriteBatchReport writes the results as JSON when fileName ends with .json and
+// as CSV otherwise
+func WriteBatchReport(fileName string, results []BatchResult) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if strings.HasSuffix(fileName, ".json") {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"file", "valid", "error_kind", "rooms", "ants", "turns", "time_ms", "timed_out", "expected", "regression"})
+	for _, result := range results {
+		writer.Write([]string{
+			result.File,
+			strconv.FormatBool(result.Valid),
+			result.ErrorKind,
+			strconv.Itoa(result.Rooms),
+			strconv.Itoa(result.Ants),
+			strconv.Itoa(result.Turns),
+			strconv.FormatFloat(float64(result.Time.Microseconds())/1000, 'f', 3, 64),
+			strconv.FormatBool(result.TimedOut),
+			result.Expected,
+			strconv.FormatBool(result.Regression),
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
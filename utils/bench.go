@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"LemIn/errorHandler"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"text/tabwriter"
+	"time"
+)
+
+// BenchResult is the measure of one solver on one map
+type BenchResult struct {
+	Turns  int
+	Time   time.Duration
+	Allocs uint64
+	Err    error
+}
+
+// Bench runs every selected solver on every map of a directory and prints the
+// turns, wall time and allocations of each run.
+func Bench(options Options) {
+	files, err := filepath.Glob(filepath.Join(options.FileName, "*.txt"))
+	errorHandler.CheckError(err, true)
+	if len(files) == 0 {
+		errorHandler.CheckError(errors.New("ERROR: no map found in "+options.FileName), true)
+		return
+	}
+
+	solverNames := options.Solvers
+	if len(solverNames) == 0 {
+		solverNames = BenchSolverNames()
+	}
+	var selected []Solver
+	for _, name := range solverNames {
+		solver, err := GetSolver(name)
+		if err != nil {
+			errorHandler.CheckError(err, true)
+			return
+		}
+		selected = append(selected, solver)
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "MAP\tSOLVER\tTURNS\tTIME\tALLOCS")
+	for _, file := range files {
+		farm, err := ReadFarm(file)
+		if err != nil {
+			fmt.Fprintf(writer, "%s\t-\t%v\t-\t-\n", filepath.Base(file), err)
+			continue
+		}
+		for i, solver := range selected {
+			ctx, cancel := withTimeout(options.Timeout)
+			result := MeasureSolver(ctx, solver, farm, WithJobs(options.Jobs), WithPathLimit(options.Paths))
+			cancel()
+			if result.Err != nil {
+				fmt.Fprintf(writer, "%s\t%s\t%v\t%v\t%d\n", filepath.Base(file), solverNames[i], result.Err, result.Time, result.Allocs)
+				continue
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%d\t%v\t%d\n", filepath.Base(file), solverNames[i], result.Turns, result.Time, result.Allocs)
+		}
+	}
+	writer.Flush()
+}
+
+// MeasureSolver runs solver once on farm
+func MeasureSolver(ctx context.Context, solver Solver, farm Farm, options ...SolveOption) BenchResult {
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	startTime := time.Now()
+
+	result, err := solver(ctx, farm, options...)
+
+	elapsed := time.Since(startTime)
+	runtime.ReadMemStats(&after)
+
+	return BenchResult{
+		Turns:  len(result.Turns),
+		Time:   elapsed,
+		Allocs: after.Mallocs - before.Mallocs,
+		Err:    err,
+	}
+}
@@ -0,0 +1,123 @@
+package utils
+
+// undirectedGraph is the graph with rooms as indexes and every tunnel once,
+// whatever its direction. Loops (a-a) are left out.
+type undirectedGraph struct {
+	adjacency [][]undirectedEdge
+	edges     [][2]int
+}
+
+type undirectedEdge struct {
+	to, id int
+}
+
+func newUndirectedGraph(graph Graph, rooms []Room) undirectedGraph {
+	roomIndex := make(map[string]int, len(rooms))
+	for i, room := range rooms {
+		roomIndex[room.Name] = i
+	}
+	// Two-way tunnels are listed from both sides, one-way ones from one
+	arcs := make(map[[2]int]int)
+	undirected := undirectedGraph{adjacency: make([][]undirectedEdge, len(rooms))}
+	for from, room := range rooms {
+		for _, neighborName := range graph.Edges[room.Name] {
+			to, exists := roomIndex[neighborName]
+			if !exists || to == from {
+				continue
+			}
+			if arcs[[2]int{to, from}] > 0 {
+				arcs[[2]int{to, from}]--
+				continue
+			}
+			arcs[[2]int{from, to}]++
+			undirected.addEdge(from, to)
+		}
+	}
+	return undirected
+}
+
+func (g *undirectedGraph) addEdge(from, to int) int {
+	id := len(g.edges)
+	g.edges = append(g.edges, [2]int{from, to})
+	g.adjacency[from] = append(g.adjacency[from], undirectedEdge{to: to, id: id})
+	g.adjacency[to] = append(g.adjacency[to], undirectedEdge{to: from, id: id})
+	return id
+}
+
+// biconnectedComponents splits the edges in biconnected components (Tarjan)
+// and marks the articulation points, the rooms whose removal disconnects their
+// part of the graph.
+func (g undirectedGraph) biconnectedComponents() ([][]int, []bool) {
+	discovery := make([]int, len(g.adjacency))
+	low := make([]int, len(g.adjacency))
+	articulation := make([]bool, len(g.adjacency))
+	var components [][]int
+	var stack []int
+	timer := 0
+
+	var visit func(room, parentEdge int)
+	visit = func(room, parentEdge int) {
+		timer++
+		discovery[room] = timer
+		low[room] = timer
+		children := 0
+		for _, edge := range g.adjacency[room] {
+			if edge.id == parentEdge {
+				continue
+			}
+			if discovery[edge.to] == 0 {
+				children++
+				stack = append(stack, edge.id)
+				visit(edge.to, edge.id)
+				low[room] = min(low[room], low[edge.to])
+				if low[edge.to] >= discovery[room] {
+					if parentEdge != -1 || children > 1 {
+						articulation[room] = true
+					}
+					var component []int
+					for {
+						id := stack[len(stack)-1]
+						stack = stack[:len(stack)-1]
+						component = append(component, id)
+						if id == edge.id {
+							break
+						}
+					}
+					components = append(components, component)
+				}
+			} else if discovery[edge.to] < discovery[room] {
+				stack = append(stack, edge.id)
+				low[room] = min(low[room], discovery[edge.to])
+			}
+		}
+	}
+	for room := range g.adjacency {
+		if discovery[room] == 0 {
+			visit(room, -1)
+		}
+	}
+	return components, articulation
+}
+
+// connectedComponents returns the rooms of each connected component
+func (g undirectedGraph) connectedComponents() [][]int {
+	seen := make([]bool, len(g.adjacency))
+	var components [][]int
+	for root := range g.adjacency {
+		if seen[root] {
+			continue
+		}
+		seen[root] = true
+		component := []int{root}
+		for i := 0; i < len(component); i++ {
+			for _, edge := range g.adjacency[component[i]] {
+				if !seen[edge.to] {
+					seen[edge.to] = true
+					component = append(component, edge.to)
+				}
+			}
+		}
+		components = append(components, component)
+	}
+	return components
+}
@@ -8,23 +8,52 @@ import (
 )
 
 func CheckContent(fileContent []string) (int, []Room, []Tunnel) {
+	numberOfAnts, rooms, tunnels, err := ParseContent(fileContent)
+	if err != nil {
+		errorHandler.CheckError(err, true)
+		return -1, nil, nil
+	}
+	return numberOfAnts, rooms, tunnels
+}
+
+// ParseError is an error of an input file with the line, counted from 1,
+// where it was found. Line is 0 when the error is about the whole file. The
+// message is the one of Err so what lem-in prints does not change.
+type ParseError struct {
+	Line int
+	Err  error
+}
+
+func (e *ParseError) Error() string { return e.Err.Error() }
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+func parseError(line int, message string) error {
+	return &ParseError{Line: line, Err: errors.New(message)}
+}
+
+// ParseContent is CheckContent returning the error instead of exiting, every
+// error is a *ParseError.
+func ParseContent(fileContent []string) (int, []Room, []Tunnel, error) {
 	var numberOfAnts int
 	var rooms []Room
 	var tunnels []Tunnel
+	var lineNumbers []int
 	var err error
 
 	if len(fileContent) < 6 {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format"), true)
-		return -1, nil, nil
+		return -1, nil, nil, parseError(0, "ERROR: invalid data format")
 	}
-	fileContent, rooms = ExtractComments(fileContent, rooms)
-	if fileContent == nil {
-		return -1, nil, nil
+	fileContent, lineNumbers, rooms, err = extractComments(fileContent, rooms)
+	if err != nil {
+		return -1, nil, nil, err
+	}
+	if len(fileContent) == 0 {
+		return -1, nil, nil, parseError(0, "ERROR: invalid data format, invalid number of Ants")
 	}
 	numberOfAnts, err = strconv.Atoi(fileContent[0])
 	if err != nil || numberOfAnts < 1 {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, invalid number of Ants"), true)
-		return -1, nil, nil
+		return -1, nil, nil, parseError(lineNumbers[0], "ERROR: invalid data format, invalid number of Ants")
 	}
 	size := len(fileContent)
 	index := 1
@@ -36,99 +65,118 @@ func CheckContent(fileContent []string) (int, []Room, []Tunnel) {
 				index = i
 				break
 			} else {
-				errorHandler.CheckError(errors.New("ERROR: invalid data format"), true)
-				return -1, nil, nil
+				return -1, nil, nil, parseError(lineNumbers[i], "ERROR: invalid data format")
 			}
 		}
-		rooms = append(rooms, MakeRoom(fileContent[i]))
+		room, err := ParseRoom(fileContent[i])
+		if err != nil {
+			return -1, nil, nil, &ParseError{Line: lineNumbers[i], Err: err}
+		}
+		rooms = append(rooms, room)
 	}
 
 	if len(rooms) == 0 {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, no rooms found"), true)
-		return -1, nil, nil
+		return -1, nil, nil, parseError(0, "ERROR: invalid data format, no rooms found")
 	}
 
 	// Tunnels should be after the defination of rooms
 	for i := index; i < size; i++ {
 		if !IsTunnel(fileContent[i]) {
-			errorHandler.CheckError(errors.New("ERROR: invalid data format"), true)
-			return -1, nil, nil
+			return -1, nil, nil, parseError(lineNumbers[i], "ERROR: invalid data format")
+		}
+		tunnel, err := ParseTunnel(fileContent[i], rooms)
+		if err != nil {
+			return -1, nil, nil, &ParseError{Line: lineNumbers[i], Err: err}
 		}
-		tunnels = append(tunnels, MakeTunnel(fileContent[i], rooms))
+		tunnels = append(tunnels, tunnel)
 	}
 
 	if len(tunnels) == 0 {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, no tunnel found"), true)
-		return -1, nil, nil
+		return -1, nil, nil, parseError(0, "ERROR: invalid data format, no tunnel found")
 	}
 
-	if !checkUniqueName(rooms) {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, invalid room format, duplicate room names"), true)
-		return -1, nil, nil
+	if line := duplicateNameLine(rooms, fileContent, lineNumbers); line != -1 {
+		return -1, nil, nil, parseError(line, "ERROR: invalid data format, invalid room format, duplicate room names")
 	}
 
-	return numberOfAnts, rooms, tunnels
+	return numberOfAnts, rooms, tunnels, nil
 }
 
 func ExtractComments(fileContent []string, rooms []Room) ([]string, []Room) {
+	modifiedContent, _, rooms, err := extractComments(fileContent, rooms)
+	if err != nil {
+		errorHandler.CheckError(err, true)
+		return nil, []Room{}
+	}
+	return modifiedContent, rooms
+}
+
+// extractComments removes the comments and the start and end rooms from the
+// content, lineNumbers gives the line of the file each remaining line comes from.
+func extractComments(fileContent []string, rooms []Room) ([]string, []int, []Room, error) {
 	var modifiedContent []string
+	var lineNumbers []int
 	var start Room
 	var end Room
+	var err error
 	size := len(fileContent)
 	startFlag := false
 	endFlag := false
 	for i := 0; i < size; i++ {
 		if strings.ToLower(fileContent[i]) == "##start" {
 			if startFlag {
-				errorHandler.CheckError(errors.New("ERROR: invalid data format, more than one start room found"), true)
-				return nil, []Room{}
+				return nil, nil, []Room{}, parseError(i+1, "ERROR: invalid data format, more than one start room found")
 			}
 
 			startFlag = true
 
 			if i == size-1 {
-				errorHandler.CheckError(errors.New("ERROR: invalid data format, no start room found"), true)
-				return nil, []Room{}
+				return nil, nil, []Room{}, parseError(i+1, "ERROR: invalid data format, no start room found")
 			}
 
-			start = MakeRoom(fileContent[i+1])
+			start, err = ParseRoom(fileContent[i+1])
+			if err != nil {
+				return nil, nil, []Room{}, &ParseError{Line: i + 2, Err: err}
+			}
 			start.IsStart = true
 			rooms = append(rooms, start)
 			i++
 		} else if strings.ToLower(fileContent[i]) == "##end" {
 			if endFlag {
-				errorHandler.CheckError(errors.New("ERROR: invalid data format, more than one end room found"), true)
-				return nil, []Room{}
+				return nil, nil, []Room{}, parseError(i+1, "ERROR: invalid data format, more than one end room found")
 			}
 
 			endFlag = true
 
 			if i == size-1 {
-				errorHandler.CheckError(errors.New("ERROR: invalid data format, no end room found"), true)
-				return nil, []Room{}
+				return nil, nil, []Room{}, parseError(i+1, "ERROR: invalid data format, no end room found")
 			}
 
-			end = MakeRoom(fileContent[i+1])
+			end, err = ParseRoom(fileContent[i+1])
+			if err != nil {
+				return nil, nil, []Room{}, &ParseError{Line: i + 2, Err: err}
+			}
 			end.IsEnd = true
 			rooms = append(rooms, end)
 			i++
 		} else if !strings.HasPrefix(fileContent[i], "#") {
 			modifiedContent = append(modifiedContent, fileContent[i])
+			lineNumbers = append(lineNumbers, i+1)
 		}
 	}
 	if !startFlag {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, no start room found"), true)
-		return nil, []Room{}
+		return nil, nil, []Room{}, parseError(0, "ERROR: invalid data format, no start room found")
 	} else if !endFlag {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, no end room found"), true)
-		return nil, []Room{}
+		return nil, nil, []Room{}, parseError(0, "ERROR: invalid data format, no end room found")
 	}
 
-	return modifiedContent, rooms
+	return modifiedContent, lineNumbers, rooms, nil
 }
-
 func IsTunnel(line string) bool {
-	splittedLine := strings.Split(line, "-")
+	if strings.Contains(line, "-") && strings.Contains(line, ">") {
+		return false
+	}
+	splittedLine := strings.Split(line, tunnelSeparator(line))
 	if len(splittedLine) != 2 {
 		return false
 	}
@@ -139,13 +187,47 @@ func IsTunnel(line string) bool {
 }
 
 func IsRoom(line string) bool {
-	if strings.Contains(line, "-") {
+	if strings.Contains(line, "-") || strings.Contains(line, ">") {
 		return false
 	}
 	splittedLine := strings.Split(line, " ")
 	return len(splittedLine) == 3
 }
 
+// tunnelSeparator returns ">" for one-way tunnels (a>b) and "-" for the
+// usual two-way tunnels (a-b).
+func tunnelSeparator(line string) string {
+	if strings.Contains(line, ">") {
+		return ">"
+	}
+	return "-"
+}
+
+// duplicateNameLine returns the line of the first room whose name was already
+// used, or -1. Start and end rooms are not in fileContent, their line is unknown.
+func duplicateNameLine(rooms []Room, fileContent []string, lineNumbers []int) int {
+	if checkUniqueName(rooms) {
+		return -1
+	}
+	seen := make(map[string]bool)
+	for _, room := range rooms {
+		if room.IsStart || room.IsEnd {
+			seen[room.Name] = true
+		}
+	}
+	for i, line := range fileContent {
+		if !IsRoom(line) {
+			continue
+		}
+		name, _, _ := strings.Cut(line, " ")
+		if seen[name] {
+			return lineNumbers[i]
+		}
+		seen[name] = true
+	}
+	return 0
+}
+
 func checkUniqueName(rooms []Room) bool {
 	for i := 0; i < len(rooms); i++ {
 		for j := i + 1; j < len(rooms); j++ {
@@ -0,0 +1,143 @@
+package utils
+
+import (
+	"LemIn/errorHandler"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+// Criticality is what happens to a solution when a room or tunnel is removed
+type Criticality struct {
+	Element      string `json:"element"`
+	Kind         string `json:"kind"` // room or tunnel
+	Turns        int    `json:"turns"`
+	Disconnected bool   `json:"disconnected"`
+	Impact       int    `json:"impact"` // turns added
+}
+
+// CriticalityReport prints the rooms and tunnels of a farm ranked by how many turns
+// the ants lose when they are removed
+func CriticalityReport(options Options) {
+	farm, err := ReadFarm(options.FileName)
+	if err != nil {
+		errorHandler.CheckError(err, true)
+		return
+	}
+	ctx, cancel := withTimeout(options.Timeout)
+	defer cancel()
+	baseline, elements, err := AnalyzeCriticality(ctx, farm, WithJobs(options.Jobs), WithPathLimit(options.Paths))
+	if err != nil {
+		errorHandler.CheckError(err, true)
+		return
+	}
+	if ctx.Err() != nil {
+		log.Printf("Warning: timeout reached, only %d elements were checked\n", len(elements))
+	}
+	PrintCriticality(baseline, elements)
+}
+
+// AnalyzeCriticality solves the farm again without each room on the paths
+// SolvePaths chose with options or next to them, and without each tunnel of
+// those rooms. Every solve starts from a copy of the flow of an
+// IncrementalSolver, so only the paths through the removed element are
+// searched again. Elements come sorted by impact, the ones disconnecting the
+// end room first. When ctx is done the elements checked so far are returned,
+// an element whose solve was cut short is left out.
+func AnalyzeCriticality(ctx context.Context, farm Farm, options ...SolveOption) (int, []Criticality, error) {
+	result, err := SolvePaths(ctx, farm, options...)
+	if err != nil {
+		return 0, nil, err
+	}
+	baseline := len(result.Turns)
+	if ctx.Err() != nil {
+		// The baseline may be cut short, no impact can be measured from it
+		return baseline, nil, nil
+	}
+	solver := NewIncrementalSolver(farm)
+	if _, err := solver.Solve(ctx); err != nil {
+		if ctx.Err() != nil {
+			return baseline, nil, nil
+		}
+		return 0, nil, err
+	}
+
+	near := make(map[string]bool)
+	for _, path := range result.Paths {
+		for _, roomName := range path {
+			near[roomName] = true
+			for _, neighborName := range farm.Graph.Edges[roomName] {
+				near[neighborName] = true
+			}
+		}
+	}
+	for _, neighborName := range farm.Graph.Edges[farm.Start.Name] {
+		near[neighborName] = true
+	}
+	delete(near, farm.Start.Name)
+	delete(near, farm.End.Name)
+
+	var elements []Criticality
+	check := func(element, kind string, remove func(*IncrementalSolver) error) {
+		clone := solver.Clone()
+		if err := remove(clone); err != nil {
+			return
+		}
+		criticality := Criticality{Element: element, Kind: kind}
+		result, err := clone.Solve(ctx)
+		if ctx.Err() != nil {
+			// A solve cut short says nothing about the element
+			return
+		}
+		if err != nil {
+			criticality.Disconnected = true
+		} else {
+			criticality.Turns = len(result.Turns)
+			criticality.Impact = criticality.Turns - baseline
+		}
+		elements = append(elements, criticality)
+	}
+
+	for _, room := range farm.Rooms {
+		if near[room.Name] && ctx.Err() == nil {
+			check(room.Name, "room", func(clone *IncrementalSolver) error { return clone.RemoveRoom(room.Name) })
+		}
+	}
+	checked := make(map[[2]string]bool)
+	for _, tunnel := range farm.Tunnels {
+		from, to := tunnel.FromRoom.Name, tunnel.ToRoom.Name
+		pair := roomPair(from, to)
+		if checked[pair] || ctx.Err() != nil {
+			continue
+		}
+		if near[from] || near[to] || pair == roomPair(farm.Start.Name, farm.End.Name) {
+			checked[pair] = true
+			check(from+"-"+to, "tunnel", func(clone *IncrementalSolver) error { return clone.RemoveTunnel(from, to) })
+		}
+	}
+
+	sort.SliceStable(elements, func(i, j int) bool {
+		if elements[i].Disconnected != elements[j].Disconnected {
+			return elements[i].Disconnected
+		}
+		return elements[i].Impact > elements[j].Impact
+	})
+	return baseline, elements, nil
+}
+
+func PrintCriticality(baseline int, elements []Criticality) {
+	fmt.Printf("%d turns with every room and tunnel, %d elements checked\n", baseline, len(elements))
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ELEMENT\tKIND\tTURNS\tIMPACT")
+	for _, element := range elements {
+		if element.Disconnected {
+			fmt.Fprintf(writer, "%s\t%s\tdisconnected\t-\n", element.Element, element.Kind)
+		} else {
+			fmt.Fprintf(writer, "%s\t%s\t%d\t%+d\n", element.Element, element.Kind, element.Turns, element.Impact)
+		}
+	}
+	writer.Flush()
+}
@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"LemIn/errorHandler"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// CurvePoint is the fewest turns needed to bring a number of ants to the end
+// room, and how many paths of the best group can be used in that many turns
+type CurvePoint struct {
+	Ants  int
+	Turns int
+	Paths int
+}
+
+// Curve prints the turns needed for every number of ants of options.Ants as
+// CSV, and writes them as an SVG chart when options.SVG is set
+func Curve(options Options) {
+	farm, err := ReadFarm(options.FileName)
+	if err != nil {
+		errorHandler.CheckError(err, true)
+		return
+	}
+	fromAnts, toAnts := options.Ants[0], options.Ants[1]
+	if toAnts == 0 {
+		fromAnts, toAnts = 1, farm.NumberOfAnts
+	}
+
+	ctx, cancel := withTimeout(options.Timeout)
+	defer cancel()
+	points, err := TurnsCurve(ctx, farm, fromAnts, toAnts, WithPathLimit(options.Paths), WithJobs(options.Jobs))
+	if ctx.Err() != nil && len(points) > 0 {
+		log.Printf("Warning: timeout reached, the curve stops at %d ants\n", points[len(points)-1].Ants)
+	} else if err != nil {
+		errorHandler.CheckError(err, true)
+		return
+	}
+	fmt.Println("ants,turns,paths")
+	for _, point := range points {
+		fmt.Printf("%d,%d,%d\n", point.Ants, point.Turns, point.Paths)
+	}
+	if options.SVG != "" {
+		errorHandler.CheckError(WriteCurveSVG(options.SVG, points), true)
+	}
+}
+
+// ParseAntsRange reads the --ants value of lem-in curve, like 1..10000 or 500
+func ParseAntsRange(value string) ([2]int, error) {
+	first, last, isRange := strings.Cut(value, "..")
+	from, err := strconv.Atoi(first)
+	to := from
+	if err == nil && isRange {
+		to, err = strconv.Atoi(last)
+	}
+	if err != nil || from < 1 || to < from {
+		return [2]int{}, errors.New("ERROR: invalid --ants value " + value)
+	}
+	return [2]int{from, to}, nil
+}
+
+// TurnsCurve returns the fewest turns for every number of ants from fromAnts
+// to toAnts. The paths are found once. With T turns a path of l tunnels brings
+// T-l+1 ants to the end room, so once the group search found the best group
+// and its T for some ants, every number of ants up to what that group brings
+// in T turns takes T turns too, and the next search starts after them. A group
+// using as many paths as there can be without shared rooms stays the best for
+// any more ants: each extra turn brings one more ant per path, no other group
+// brings more. When ctx is done the points found so far are returned with
+// ctx.Err().
+func TurnsCurve(ctx context.Context, farm Farm, fromAnts, toAnts int, options ...SolveOption) ([]CurvePoint, error) {
+	// The number of shortest paths chosen from the farm depends on the ants
+	farm.NumberOfAnts = toAnts
+	config := makeSolveConfig(options)
+	allPaths, _, err := findSolverPaths(ctx, farm, config, KShortestPaths)
+	if err != nil {
+		return nil, err
+	}
+	maxPaths := MaxDisjointPaths(farm.Graph, farm.Rooms, farm.Start, farm.End, toAnts)
+
+	var points []CurvePoint
+	for ants := fromAnts; ants <= toAnts; {
+		group := SearchBestPathGroup(ctx, allPaths, ants, config.Jobs)
+		if ctx.Err() != nil {
+			return points, ctx.Err()
+		}
+		turns := PredictTurns(group, ants)
+		capacity, paths := 0, 0
+		for _, path := range group {
+			if len(path) <= turns {
+				capacity += turns - len(path) + 1
+				paths++
+			}
+		}
+		for ; ants <= toAnts && ants <= capacity; ants++ {
+			points = append(points, CurvePoint{Ants: ants, Turns: turns, Paths: paths})
+		}
+		if paths == maxPaths {
+			for ; ants <= toAnts; ants++ {
+				extraTurns := (ants - capacity + paths - 1) / paths
+				points = append(points, CurvePoint{Ants: ants, Turns: turns + extraTurns, Paths: paths})
+			}
+		}
+	}
+	return points, nil
+}
+
+// WriteCurveSVG draws the turns against the ants as a line chart, with a
+// dashed line where the best group starts using more paths
+func WriteCurveSVG(fileName string, points []CurvePoint) error {
+	if len(points) == 0 {
+		return errors.New("ERROR: no point to draw")
+	}
+	const width, height, margin = 800, 400, 60
+	first, last := points[0], points[len(points)-1]
+	x := func(ants int) float64 {
+		if last.Ants == first.Ants {
+			return margin
+		}
+		return margin + float64(ants-first.Ants)*(width-2*margin)/float64(last.Ants-first.Ants)
+	}
+	y := func(turns int) float64 {
+		if last.Turns == first.Turns {
+			return height - margin
+		}
+		return height - margin - float64(turns-first.Turns)*(height-2*margin)/float64(last.Turns-first.Turns)
+	}
+
+	var svg strings.Builder
+	fmt.Fprintf(&svg, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" font-family=\"sans-serif\" font-size=\"12\">\n", width, height)
+	fmt.Fprintf(&svg, "<rect width=\"%d\" height=\"%d\" fill=\"white\"/>\n", width, height)
+	fmt.Fprintf(&svg, "<path d=\"M%d %d V%d H%d\" stroke=\"black\" fill=\"none\"/>\n", margin, margin, height-margin, width-margin)
+	fmt.Fprintf(&svg, "<text x=\"%d\" y=\"%d\" text-anchor=\"middle\">ants</text>\n", width/2, height-margin/3)
+	fmt.Fprintf(&svg, "<text x=\"%d\" y=\"%d\" text-anchor=\"middle\">turns</text>\n", margin, margin-20)
+	fmt.Fprintf(&svg, "<text x=\"%.1f\" y=\"%d\" text-anchor=\"middle\">%d</text>\n", x(first.Ants), height-margin+15, first.Ants)
+	fmt.Fprintf(&svg, "<text x=\"%.1f\" y=\"%d\" text-anchor=\"middle\">%d</text>\n", x(last.Ants), height-margin+15, last.Ants)
+	fmt.Fprintf(&svg, "<text x=\"%d\" y=\"%.1f\" text-anchor=\"end\">%d</text>\n", margin-5, y(first.Turns)+4, first.Turns)
+	fmt.Fprintf(&svg, "<text x=\"%d\" y=\"%.1f\" text-anchor=\"end\">%d</text>\n", margin-5, y(last.Turns)+4, last.Turns)
+
+	// Only the points where the turns change are needed for the line
+	svg.WriteString("<polyline fill=\"none\" stroke=\"steelblue\" stroke-width=\"2\" points=\"")
+	for i, point := range points {
+		if i == 0 || i == len(points)-1 || point.Turns != points[i-1].Turns {
+			fmt.Fprintf(&svg, "%.1f,%.1f ", x(point.Ants), y(point.Turns))
+		}
+	}
+	svg.WriteString("\"/>\n")
+
+	for i := 1; i < len(points); i++ {
+		if points[i].Paths != points[i-1].Paths {
+			fmt.Fprintf(&svg, "<line x1=\"%.1f\" y1=\"%d\" x2=\"%.1f\" y2=\"%d\" stroke=\"gray\" stroke-dasharray=\"4\"/>\n",
+				x(points[i].Ants), margin, x(points[i].Ants), height-margin)
+			fmt.Fprintf(&svg, "<text x=\"%.1f\" y=\"%d\" fill=\"gray\">%d paths from %d ants</text>\n",
+				x(points[i].Ants)+3, margin+12, points[i].Paths, points[i].Ants)
+		}
+	}
+	svg.WriteString("</svg>\n")
+	return os.WriteFile(fileName, []byte(svg.String()), 0o644)
+}
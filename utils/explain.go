@@ -0,0 +1,138 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GroupExplanation is one group of paths FindBestPathGroup compared
+type GroupExplanation struct {
+	Paths   [][]string `json:"paths"`
+	Lengths []int      `json:"lengths"` // tunnels of each path
+	Ants    []int      `json:"ants"`    // ants sent on each path
+	Turns   int        `json:"turns"`
+	score   GroupScore // as the group search scores it
+}
+
+// Explanation tells how the group of paths was chosen
+type Explanation struct {
+	Groups       int                `json:"groups"`        // groups of paths without shared rooms
+	PrunedGroups int                `json:"pruned_groups"` // groups removed by RemoveSmallerGroups
+	Candidates   []GroupExplanation `json:"candidates"`    // the best groups, the chosen one first
+	Objective    Objective          `json:"-"`
+}
+
+// ExplainFarm runs the steps of SolvePaths one by one and keeps the top
+// groups, ranked the way the group search compares them. The groups are all
+// kept in memory, use a timeout or a path limit on big farms.
+func ExplainFarm(ctx context.Context, farm Farm, top int, options ...SolveOption) (Explanation, error) {
+	config := makeSolveConfig(options)
+	allPaths, _, err := findSolverPaths(ctx, farm, config, KShortestPaths)
+	if err != nil {
+		return Explanation{}, err
+	}
+	groups := FilterNonIntersectingGroupsContext(ctx, allPaths)
+	maximalGroups := RemoveSmallerGroupsContext(ctx, groups)
+	explanation := Explanation{Groups: len(groups), PrunedGroups: len(groups) - len(maximalGroups)}
+
+	explanation.Candidates, explanation.Objective = rankGroups(allPaths, maximalGroups, farm.NumberOfAnts, config.Objective)
+	if len(explanation.Candidates) > top {
+		explanation.Candidates = explanation.Candidates[:top]
+	}
+	return explanation, nil
+}
+
+// rankGroups scores groups made of paths of allPaths with the group search,
+// and sorts them the way it compares them: on the objective, then on the order
+// of their paths in allPaths. It also returns the objective used.
+func rankGroups(allPaths [][]Room, groups [][][]Room, ants int, objective Objective) ([]GroupExplanation, Objective) {
+	lengths := make([]int, len(allPaths))
+	pathIndexes := make(map[string]int, len(allPaths))
+	for i, path := range allPaths {
+		lengths[i] = len(path)
+		pathIndexes[roomNamesKey(path)] = i
+	}
+	search := newGroupSearch(allPaths, lengths, ants, objective)
+
+	candidates := make([]groupCandidate, len(groups))
+	for i, group := range groups {
+		for _, path := range group {
+			candidates[i].indexes = append(candidates[i].indexes, pathIndexes[roomNamesKey(path)])
+		}
+		sort.Ints(candidates[i].indexes)
+		candidates[i].score = search.groupScore(candidates[i].indexes)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return search.better(candidates[i], candidates[j])
+	})
+
+	explanations := make([]GroupExplanation, len(candidates))
+	for i, candidate := range candidates {
+		explanation := &explanations[i]
+		for _, pathIndex := range candidate.indexes {
+			var pathNames []string
+			for _, room := range allPaths[pathIndex][1:] {
+				pathNames = append(pathNames, room.Name)
+			}
+			explanation.Paths = append(explanation.Paths, pathNames)
+			explanation.Lengths = append(explanation.Lengths, len(pathNames))
+		}
+		explanation.Ants = assignAntsToPaths(explanation.Lengths, ants)
+		explanation.Turns = PredictTurns(explanation.Paths, ants)
+		explanation.score = candidate.score
+	}
+	return explanations, search.objective
+}
+
+func roomNamesKey(path []Room) string {
+	names := make([]string, len(path))
+	for i, room := range path {
+		names[i] = room.Name
+	}
+	return strings.Join(names, " ")
+}
+
+// Bottleneck returns the path whose last ant arrives last
+func (g GroupExplanation) Bottleneck() int {
+	bottleneck := 0
+	for i := range g.Paths {
+		if g.Ants[i] > 0 && g.Lengths[i]+g.Ants[i] > g.Lengths[bottleneck]+g.Ants[bottleneck] {
+			bottleneck = i
+		}
+	}
+	return bottleneck
+}
+
+func PrintExplanation(explanation Explanation) {
+	fmt.Printf("explain: %d groups of paths without shared rooms, %d pruned as part of a bigger group, %d compared\n",
+		explanation.Groups, explanation.PrunedGroups, explanation.Groups-explanation.PrunedGroups)
+	if len(explanation.Candidates) == 0 {
+		return
+	}
+	winner := explanation.Candidates[0]
+	for rank, candidate := range explanation.Candidates {
+		bottleneck := candidate.Bottleneck()
+		switch {
+		case rank == 0:
+			fmt.Printf("#1 %d turns, chosen\n", candidate.Turns)
+		case candidate.Turns == winner.Turns:
+			reason := "found after the chosen group"
+			for _, criterion := range explanation.Objective {
+				if difference := candidate.score[criterion] - winner.score[criterion]; difference > 0 {
+					reason = fmt.Sprintf("%d more %s", difference, criterionNames[criterion])
+					break
+				}
+			}
+			fmt.Printf("#%d %d turns, as fast but %s\n", rank+1, candidate.Turns, reason)
+		default:
+			fmt.Printf("#%d %d turns, %d more: the last ant of path %d (%d tunnels, %d ants) arrives at turn %d\n",
+				rank+1, candidate.Turns, candidate.Turns-winner.Turns, bottleneck+1,
+				candidate.Lengths[bottleneck], candidate.Ants[bottleneck], candidate.Turns)
+		}
+		for i, path := range candidate.Paths {
+			fmt.Printf("   path %d: %d tunnels, %d ants: %s\n", i+1, candidate.Lengths[i], candidate.Ants[i], strings.Join(path, " "))
+		}
+	}
+}
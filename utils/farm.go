@@ -0,0 +1,47 @@
+package utils
+
+import "LemIn/fileHandler"
+
+// Farm is a parsed input file
+type Farm struct {
+	Content      []string
+	NumberOfAnts int
+	Rooms        []Room
+	Tunnels      []Tunnel
+	Graph        Graph
+	Start        Room
+	End          Room
+}
+
+// ParseFarm checks the content of an input file and builds its graph
+func ParseFarm(fileContent []string) (Farm, error) {
+	numberOfAnts, rooms, tunnels, err := ParseContent(fileContent)
+	if err != nil {
+		return Farm{}, err
+	}
+
+	graph := CreateGraph(tunnels)
+	graph.Vertices = len(rooms)
+
+	_, startRoom := FindStart(rooms)
+	_, endRoom := FindEnd(rooms)
+
+	return Farm{
+		Content:      fileContent,
+		NumberOfAnts: numberOfAnts,
+		Rooms:        rooms,
+		Tunnels:      tunnels,
+		Graph:        graph,
+		Start:        startRoom,
+		End:          endRoom,
+	}, nil
+}
+
+// ReadFarm reads and parses an input file
+func ReadFarm(fileName string) (Farm, error) {
+	fileContent, err := fileHandler.ReadLines(fileName)
+	if err != nil {
+		return Farm{}, err
+	}
+	return ParseFarm(fileContent)
+}
@@ -0,0 +1,268 @@
+package utils
+
+import (
+	"math"
+	"slices"
+)
+
+// flowEdge is one direction of an edge in the residual network. The reverse
+// edge is adj[to][rev], its cost is the opposite.
+type flowEdge struct {
+	to       int
+	rev      int
+	cap      int
+	capacity int
+	cost     int
+}
+
+// flowNetwork is a small Dinic max flow implementation used by the solvers
+// that need capacities on rooms and tunnels. maxFlow gives up when stop says
+// so, the flow sent until then is kept.
+type flowNetwork struct {
+	adj   [][]flowEdge
+	level []int
+	next  []int
+	stop  *stopChecker
+}
+
+func newFlowNetwork(nodes int) *flowNetwork {
+	return &flowNetwork{adj: make([][]flowEdge, nodes)}
+}
+
+func (f *flowNetwork) addNode() int {
+	f.adj = append(f.adj, nil)
+	return len(f.adj) - 1
+}
+
+func (f *flowNetwork) addEdge(from, to, capacity int) {
+	f.adj[from] = append(f.adj[from], flowEdge{to: to, rev: len(f.adj[to]), cap: capacity, capacity: capacity})
+	f.adj[to] = append(f.adj[to], flowEdge{to: from, rev: len(f.adj[from]) - 1})
+}
+
+// flow returns how many units are sent over the edge adj[node][index].
+func (f *flowNetwork) flow(node, index int) int {
+	return f.adj[node][index].capacity - f.adj[node][index].cap
+}
+
+// setCost sets the cost of sending one unit over adj[node][index]
+func (f *flowNetwork) setCost(node, index, cost int) {
+	edge := &f.adj[node][index]
+	edge.cost = cost
+	f.adj[edge.to][edge.rev].cost = -cost
+}
+
+// pushEdge sends one unit over an edge from -> to that still has room for it,
+// so a flow found earlier can be put back before looking for more.
+func (f *flowNetwork) pushEdge(from, to int) bool {
+	for index := range f.adj[from] {
+		edge := &f.adj[from][index]
+		if edge.to == to && edge.cap > 0 && edge.capacity > 0 {
+			edge.cap--
+			f.adj[to][edge.rev].cap++
+			return true
+		}
+	}
+	return false
+}
+
+// maxFlow pushes flow from source to sink until no augmenting path is left or
+// limit units are sent, and returns the amount of flow sent by this call.
+func (f *flowNetwork) maxFlow(source, sink, limit int) int {
+	total := 0
+	for total < limit && f.buildLevels(source, sink) {
+		f.next = make([]int, len(f.adj))
+		for total < limit {
+			pushed := f.push(source, sink, limit-total)
+			if pushed == 0 {
+				break
+			}
+			total += pushed
+		}
+	}
+	return total
+}
+
+func (f *flowNetwork) buildLevels(source, sink int) bool {
+	f.level = make([]int, len(f.adj))
+	for i := range f.level {
+		f.level[i] = -1
+	}
+	f.level[source] = 0
+	queue := []int{source}
+	for len(queue) > 0 {
+		if f.stop.stopped() {
+			return false
+		}
+		node := queue[0]
+		queue = queue[1:]
+		for _, edge := range f.adj[node] {
+			if edge.cap > 0 && f.level[edge.to] == -1 {
+				f.level[edge.to] = f.level[node] + 1
+				queue = append(queue, edge.to)
+			}
+		}
+	}
+	return f.level[sink] != -1
+}
+
+func (f *flowNetwork) push(node, sink, amount int) int {
+	if node == sink {
+		return amount
+	}
+	for ; f.next[node] < len(f.adj[node]); f.next[node]++ {
+		if f.stop.stopped() {
+			return 0
+		}
+		edge := &f.adj[node][f.next[node]]
+		if edge.cap <= 0 || f.level[edge.to] != f.level[node]+1 {
+			continue
+		}
+		pushed := f.push(edge.to, sink, min(amount, edge.cap))
+		if pushed > 0 {
+			edge.cap -= pushed
+			f.adj[edge.to][edge.rev].cap += pushed
+			return pushed
+		}
+	}
+	return 0
+}
+
+// reachable returns the nodes that can still be reached from source in the
+// residual network, which is the source side of a minimum cut after maxFlow.
+func (f *flowNetwork) reachable(source int) []bool {
+	seen := make([]bool, len(f.adj))
+	seen[source] = true
+	stack := []int{source}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, edge := range f.adj[node] {
+			if edge.cap > 0 && !seen[edge.to] {
+				seen[edge.to] = true
+				stack = append(stack, edge.to)
+			}
+		}
+	}
+	return seen
+}
+
+// cheapestPath returns the cheapest path from source to sink in the residual
+// network as node and edge index pairs, or nil when there is none or stop says
+// so. Costs may be negative as long as there is no negative cycle.
+func (f *flowNetwork) cheapestPath(source, sink int) [][2]int {
+	distance := make([]int, len(f.adj))
+	parent := make([][2]int, len(f.adj))
+	queued := make([]bool, len(f.adj))
+	for i := range distance {
+		distance[i] = math.MaxInt
+	}
+	distance[source] = 0
+	queue := []int{source}
+	queued[source] = true
+	for len(queue) > 0 {
+		if f.stop.stopped() {
+			return nil
+		}
+		node := queue[0]
+		queue = queue[1:]
+		queued[node] = false
+		for index, edge := range f.adj[node] {
+			if edge.cap > 0 && distance[node]+edge.cost < distance[edge.to] {
+				distance[edge.to] = distance[node] + edge.cost
+				parent[edge.to] = [2]int{node, index}
+				if !queued[edge.to] {
+					queued[edge.to] = true
+					queue = append(queue, edge.to)
+				}
+			}
+		}
+	}
+	if distance[sink] == math.MaxInt {
+		return nil
+	}
+	var path [][2]int
+	for node := sink; node != source; node = parent[node][0] {
+		path = append(path, parent[node])
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// negativeCycle returns a cycle of the residual network whose edges cost less
+// than nothing, as node and edge index pairs, or nil when there is none or stop
+// says so. A node relaxed through as many edges as there are nodes is reached
+// by walking a cycle.
+func (f *flowNetwork) negativeCycle() [][2]int {
+	distance := make([]int, len(f.adj))
+	edges := make([]int, len(f.adj))
+	parent := make([][2]int, len(f.adj))
+	queued := make([]bool, len(f.adj))
+	queue := make([]int, len(f.adj))
+	for node := range queue {
+		queue[node] = node
+		queued[node] = true
+	}
+	for len(queue) > 0 {
+		if f.stop.stopped() {
+			return nil
+		}
+		node := queue[0]
+		queue = queue[1:]
+		queued[node] = false
+		for index, edge := range f.adj[node] {
+			if edge.cap <= 0 || distance[node]+edge.cost >= distance[edge.to] {
+				continue
+			}
+			distance[edge.to] = distance[node] + edge.cost
+			parent[edge.to] = [2]int{node, index}
+			edges[edge.to] = edges[node] + 1
+			if edges[edge.to] >= len(f.adj) {
+				// Walking back that many parents surely ends on the cycle
+				onCycle := edge.to
+				for range f.adj {
+					onCycle = parent[onCycle][0]
+				}
+				cycle := [][2]int{parent[onCycle]}
+				for node := parent[onCycle][0]; node != onCycle; node = parent[node][0] {
+					cycle = append(cycle, parent[node])
+				}
+				slices.Reverse(cycle)
+				return cycle
+			}
+			if !queued[edge.to] {
+				queued[edge.to] = true
+				queue = append(queue, edge.to)
+			}
+		}
+	}
+	return nil
+}
+
+// augment sends one unit along a path or a cycle of the residual network
+func (f *flowNetwork) augment(edges [][2]int) {
+	for _, nodeEdge := range edges {
+		edge := &f.adj[nodeEdge[0]][nodeEdge[1]]
+		edge.cap--
+		f.adj[edge.to][edge.rev].cap++
+	}
+}
+
+// capacities returns what is left on every edge, restoreCapacities puts it
+// back so a flow can be tried and undone
+func (f *flowNetwork) capacities() [][]int {
+	saved := make([][]int, len(f.adj))
+	for node, edges := range f.adj {
+		for _, edge := range edges {
+			saved[node] = append(saved[node], edge.cap)
+		}
+	}
+	return saved
+}
+
+func (f *flowNetwork) restoreCapacities(saved [][]int) {
+	for node := range f.adj {
+		for index := range f.adj[node] {
+			f.adj[node][index].cap = saved[node][index]
+		}
+	}
+}
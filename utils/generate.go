@@ -0,0 +1,28 @@
+package utils
+
+import "fmt"
+
+// GenerateFarm returns the lines of a farm where start and end are joined by
+// corridors of rooms that do not cross. Corridor i has length+i rooms, so the
+// solver has to decide how many of the longer corridors are worth using.
+func GenerateFarm(corridors, length, numberOfAnts int) []string {
+	lines := []string{
+		fmt.Sprint(numberOfAnts),
+		"##start",
+		"start 0 0",
+		"##end",
+		fmt.Sprint("end ", length+corridors+1, " 0"),
+	}
+	var tunnels []string
+	for corridor := 0; corridor < corridors; corridor++ {
+		previous := "start"
+		for step := 0; step < length+corridor; step++ {
+			roomName := fmt.Sprint("c", corridor, "_", step)
+			lines = append(lines, fmt.Sprint(roomName, " ", step+1, " ", corridor+1))
+			tunnels = append(tunnels, previous+"-"+roomName)
+			previous = roomName
+		}
+		tunnels = append(tunnels, previous+"-end")
+	}
+	return append(lines, tunnels...)
+}
@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// TunnelLength is the number of turns an ant needs to walk a tunnel in
+// geometric mode, the distance between its rooms rounded and at least one.
+func TunnelLength(from, to Room) int {
+	distance := math.Hypot(float64(to.Coord_x-from.Coord_x), float64(to.Coord_y-from.Coord_y))
+	return max(1, int(math.Round(distance)))
+}
+
+// PathLength is the number of turns needed to walk a path starting with the
+// start room in geometric mode
+func PathLength(path []Room) int {
+	length := 0
+	for i := 1; i < len(path); i++ {
+		length += TunnelLength(path[i-1], path[i])
+	}
+	return length
+}
+
+// SolveGeometric is SolvePaths where walking a tunnel takes TunnelLength turns
+// instead of one, so the group of paths with the earliest arrival of the last
+// ant is chosen. Ants still inside a tunnel at the end of a turn get a move
+// with Steps set.
+func SolveGeometric(ctx context.Context, farm Farm, options ...SolveOption) (Result, error) {
+	config := makeSolveConfig(options)
+
+	allPaths, prunedRooms, err := findSolverPaths(ctx, farm, config, KShortestGeometricPaths)
+	if err != nil {
+		return Result{}, err
+	}
+
+	lengths := make([]int, len(allPaths))
+	for i, path := range allPaths {
+		lengths[i] = PathLength(path)
+	}
+	order := make([]int, len(allPaths))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return lengths[order[i]] < lengths[order[j]] })
+	sortedPaths := make([][]Room, len(allPaths))
+	sortedLengths := make([]int, len(allPaths))
+	for i, pathIndex := range order {
+		sortedPaths[i] = allPaths[pathIndex]
+		sortedLengths[i] = lengths[pathIndex]
+	}
+
+	bestPathGroupNames := SearchBestWeightedPathGroup(ctx, sortedPaths, sortedLengths, farm.NumberOfAnts, config.Jobs, config.Objective)
+	turns := SimulateGeometric(bestPathGroupNames, farm.Rooms, farm.Start, farm.NumberOfAnts)
+	return Result{Paths: bestPathGroupNames, Turns: turns, PrunedRooms: prunedRooms}, nil
+}
+
+// SimulateGeometric sends the ants along paths given without the start room.
+// Each path gets the share of ants assignAntsToPaths gives for its length and
+// one ant leaves on every path each turn, ants are numbered by departure.
+func SimulateGeometric(pathsNames [][]string, rooms []Room, start Room, numberOfAnts int) [][]Move {
+	paths := changeTypeOfPaths(pathsNames, rooms)
+	if len(paths) == 0 {
+		return nil
+	}
+
+	// arrivals[p][j] is the turn the first ant of path p reaches room j of it
+	lengths := make([]int, len(paths))
+	arrivals := make([][]int, len(paths))
+	for p, path := range paths {
+		previous := start
+		for _, room := range path {
+			lengths[p] += TunnelLength(previous, room)
+			arrivals[p] = append(arrivals[p], lengths[p])
+			previous = room
+		}
+	}
+	shares := assignAntsToPaths(lengths, numberOfAnts)
+
+	type walk struct {
+		antId, path, departure int
+	}
+	var walks []walk
+	antId := 1
+	for departure := 0; antId <= numberOfAnts; departure++ {
+		for p := range paths {
+			if departure < shares[p] {
+				walks = append(walks, walk{antId: antId, path: p, departure: departure})
+				antId++
+			}
+		}
+	}
+
+	turnCount := 0
+	for p := range paths {
+		if shares[p] > 0 {
+			turnCount = max(turnCount, lengths[p]+shares[p]-1)
+		}
+	}
+	turns := make([][]Move, turnCount)
+	for _, w := range walks {
+		previousName, previousArrival := start.Name, 0
+		for j, room := range paths[w.path] {
+			arrival := arrivals[w.path][j]
+			steps := arrival - previousArrival
+			for step := 1; step < steps; step++ {
+				turn := w.departure + previousArrival + step
+				turns[turn-1] = append(turns[turn-1], Move{AntId: w.antId, RoomName: room.Name, From: previousName, Step: step, Steps: steps})
+			}
+			turn := w.departure + arrival
+			turns[turn-1] = append(turns[turn-1], Move{AntId: w.antId, RoomName: room.Name})
+			previousName, previousArrival = room.Name, arrival
+		}
+	}
+	for _, moves := range turns {
+		sort.SliceStable(moves, func(i, j int) bool { return moves[i].AntId < moves[j].AntId })
+	}
+	return turns
+}
+
+// GeometricLowerBound is LowerBound with the length of the shortest path
+// measured in turns of geometric mode
+func GeometricLowerBound(graph Graph, rooms []Room, start, end Room, ants int) int {
+	distance := geometricDistance(graph, rooms, start, end)
+	if distance == -1 {
+		return -1
+	}
+	cut := MaxDisjointPaths(graph, rooms, start, end, ants)
+	if cut == 0 {
+		return -1
+	}
+	return distance + (ants+cut-1)/cut - 1
+}
+
+// geometricDistance returns the turns needed to walk from start to end in
+// geometric mode, or -1 when end can not be reached
+func geometricDistance(graph Graph, rooms []Room, start, end Room) int {
+	roomIndex := make(map[string]int, len(rooms))
+	for i, room := range rooms {
+		roomIndex[room.Name] = i
+	}
+	distance := make([]int, len(rooms))
+	for i := range distance {
+		distance[i] = math.MaxInt
+	}
+	done := make([]bool, len(rooms))
+	distance[roomIndex[start.Name]] = 0
+	for {
+		current := -1
+		for i := range rooms {
+			if !done[i] && distance[i] != math.MaxInt && (current == -1 || distance[i] < distance[current]) {
+				current = i
+			}
+		}
+		if current == -1 {
+			return -1
+		}
+		if rooms[current].Name == end.Name {
+			return distance[current]
+		}
+		done[current] = true
+		for _, neighborName := range graph.Edges[rooms[current].Name] {
+			neighbor, exists := roomIndex[neighborName]
+			if !exists {
+				continue
+			}
+			distance[neighbor] = min(distance[neighbor], distance[current]+TunnelLength(rooms[current], rooms[neighbor]))
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package utils
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+// SearchBestPathGroup picks the same group of paths as
+// FindBestPathGroup(RemoveSmallerGroups(FilterNonIntersectingGroups(allPaths)), ants)
+// without keeping every group in memory. allPaths must be sorted by length.
+// Groups are scored while they are built and a branch is cut as soon as a
+// bound shows none of its groups can beat the best one found so far. Every
+// branch of the backtracking starts with a different first path, they are
+// shared between jobs goroutines which prune with the best time found so far
+// by any of them. Among groups with the same time the one that comes first
+// in the backtracking order wins, so the answer does not depend on scheduling.
+// When ctx is done the best group found so far is returned.
+func SearchBestPathGroup(ctx context.Context, allPaths [][]Room, ants, jobs int) [][]string {
+	lengths := make([]int, len(allPaths))
+	for i, path := range allPaths {
+		lengths[i] = len(path)
+	}
+	return SearchBestWeightedPathGroup(ctx, allPaths, lengths, ants, jobs, DefaultObjective)
+}
+
+// SearchBestWeightedPathGroup is SearchBestPathGroup where the time an ant
+// needs to walk each path is given by lengths, allPaths must be sorted by it,
+// and groups as fast as the best one are compared on the rest of objective.
+func SearchBestWeightedPathGroup(ctx context.Context, allPaths [][]Room, lengths []int, ants, jobs int, objective Objective) [][]string {
+	if len(allPaths) == 0 {
+		return nil
+	}
+	search := newGroupSearch(allPaths, lengths, ants, objective)
+
+	branches := make([]groupCandidate, len(allPaths))
+	if jobs <= 1 {
+		stop := newStopChecker(ctx)
+		for first := range allPaths {
+			branches[first] = search.searchBranch(stop, first)
+		}
+	} else {
+		var nextBranch atomic.Int64
+		var wait sync.WaitGroup
+		for job := 0; job < jobs; job++ {
+			wait.Add(1)
+			go func() {
+				defer wait.Done()
+				stop := newStopChecker(ctx)
+				for {
+					first := int(nextBranch.Add(1) - 1)
+					if first >= len(allPaths) {
+						return
+					}
+					branches[first] = search.searchBranch(stop, first)
+				}
+			}()
+		}
+		wait.Wait()
+	}
+
+	best := groupCandidate{indexes: []int{0}, time: math.MaxInt}
+	found := false
+	for _, candidate := range branches {
+		if candidate.indexes != nil && (!found || search.better(candidate, best)) {
+			best = candidate
+			found = true
+		}
+	}
+
+	// Paths getting no ant are not part of the answer
+	bestLengths := make([]int, len(best.indexes))
+	for i, pathIndex := range best.indexes {
+		bestLengths[i] = lengths[pathIndex]
+	}
+	shares := assignAntsToPaths(bestLengths, ants)
+
+	var bestPathGroupNames [][]string
+	for i, pathIndex := range best.indexes {
+		if shares[i] == 0 {
+			continue
+		}
+		var pathNames []string
+		for roomIndex, room := range allPaths[pathIndex] {
+			if roomIndex != 0 {
+				pathNames = append(pathNames, room.Name)
+			}
+		}
+		bestPathGroupNames = append(bestPathGroupNames, pathNames)
+	}
+	return bestPathGroupNames
+}
+
+// groupCandidate is a group of paths given by their index in allPaths
+type groupCandidate struct {
+	indexes []int
+	time    int
+	score   GroupScore
+}
+
+// better compares two groups on the objective, then on the order of their
+// paths
+func (s *groupSearch) better(c, other groupCandidate) bool {
+	if order := s.objective.compare(c.score, other.score); order != 0 {
+		return order < 0
+	}
+	for i := 0; i < len(c.indexes) && i < len(other.indexes); i++ {
+		if c.indexes[i] != other.indexes[i] {
+			return c.indexes[i] < other.indexes[i]
+		}
+	}
+	return len(c.indexes) < len(other.indexes)
+}
+
+type groupSearch struct {
+	lengths     []int
+	rooms       [][]int // rooms of each path without start and end
+	roomCount   int
+	startRooms  []int // rooms next to start, every path but a direct one uses one
+	directAfter []int // number of paths going straight from start to end after each index
+	ants        int
+	objective   Objective
+	bestTime    atomic.Int64 // best time found by any branch
+}
+
+func newGroupSearch(allPaths [][]Room, lengths []int, ants int, objective Objective) *groupSearch {
+	if len(objective) == 0 {
+		objective = DefaultObjective
+	}
+	search := &groupSearch{
+		lengths:   lengths,
+		rooms:     make([][]int, len(allPaths)),
+		ants:      ants,
+		objective: objective,
+	}
+	search.bestTime.Store(math.MaxInt64)
+
+	roomIds := make(map[string]int)
+	for pathIndex, path := range allPaths {
+		for i := 1; i < len(path)-1; i++ { // Skip start and end nodes
+			id, exists := roomIds[path[i].Name]
+			if !exists {
+				id = len(roomIds)
+				roomIds[path[i].Name] = id
+			}
+			search.rooms[pathIndex] = append(search.rooms[pathIndex], id)
+		}
+	}
+	search.roomCount = len(roomIds)
+
+	isStartRoom := make(map[int]bool)
+	search.directAfter = make([]int, len(allPaths))
+	for pathIndex := len(allPaths) - 1; pathIndex >= 0; pathIndex-- {
+		if pathIndex+1 < len(allPaths) {
+			search.directAfter[pathIndex] = search.directAfter[pathIndex+1]
+			if len(search.rooms[pathIndex+1]) == 0 {
+				search.directAfter[pathIndex]++
+			}
+		}
+		if len(search.rooms[pathIndex]) > 0 && !isStartRoom[search.rooms[pathIndex][0]] {
+			isStartRoom[search.rooms[pathIndex][0]] = true
+			search.startRooms = append(search.startRooms, search.rooms[pathIndex][0])
+		}
+	}
+	return search
+}
+
+// searchBranch returns the best maximal group whose first path is first
+func (s *groupSearch) searchBranch(stop *stopChecker, first int) groupCandidate {
+	var best groupCandidate
+	used := make([]bool, s.roomCount)
+	group := []int{first}
+	if s.bound(used, nil, first) > int(s.bestTime.Load()) {
+		return best
+	}
+	s.mark(used, first, true)
+	defer s.mark(used, first, false)
+
+	var backtrack func()
+	backtrack = func() {
+		last := group[len(group)-1]
+		hasNext := false
+		for i := last + 1; i < len(s.lengths) && !stop.stopped(); i++ {
+			if !s.fits(used, i) {
+				continue
+			}
+			hasNext = true
+			// Paths are sorted by length, so later paths can only do worse
+			if s.bound(used, group, i) > int(s.bestTime.Load()) {
+				break
+			}
+			s.mark(used, i, true)
+			group = append(group, i)
+			backtrack()
+			group = group[:len(group)-1]
+			s.mark(used, i, false)
+		}
+
+		// Only groups no path can be added to are kept, like RemoveSmallerGroups
+		if hasNext || !s.isMaximal(used, group) {
+			return
+		}
+		score := s.groupScore(group)
+		candidate := groupCandidate{indexes: append([]int(nil), group...), time: score[CriterionTurns], score: score}
+		if best.indexes == nil || s.better(candidate, best) {
+			best = candidate
+			s.lowerBestTime(candidate.time)
+		}
+	}
+	backtrack()
+	return best
+}
+
+func (s *groupSearch) lowerBestTime(time int) {
+	for {
+		current := s.bestTime.Load()
+		if int64(time) >= current || s.bestTime.CompareAndSwap(current, int64(time)) {
+			return
+		}
+	}
+}
+
+func (s *groupSearch) fits(used []bool, pathIndex int) bool {
+	for _, room := range s.rooms[pathIndex] {
+		if used[room] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *groupSearch) mark(used []bool, pathIndex int, value bool) {
+	for _, room := range s.rooms[pathIndex] {
+		used[room] = value
+	}
+}
+
+// isMaximal tells if no other path fits in the group. The backtracking already
+// knows no later path fits, so only the earlier ones are checked.
+func (s *groupSearch) isMaximal(used []bool, group []int) bool {
+	member := 0
+	for i := 0; i < group[len(group)-1]; i++ {
+		if member < len(group) && group[member] == i {
+			member++
+			continue
+		}
+		if s.fits(used, i) {
+			return false
+		}
+	}
+	return true
+}
+
+// groupScore scores a group, its turns are the time FindBestPathGroup gives it
+func (s *groupSearch) groupScore(group []int) GroupScore {
+	pathLengths := make([]int, len(group))
+	pathRooms := make([]int, len(group))
+	for i, pathIndex := range group {
+		pathLengths[i] = s.lengths[pathIndex]
+		pathRooms[i] = len(s.rooms[pathIndex])
+	}
+	return scoreGroup(pathLengths, pathRooms, assignAntsToPaths(pathLengths, s.ants))
+}
+
+// bound is a time no group made of group, path next and paths after next can
+// beat. Those later paths are at least as long as next, so the bound pretends
+// they all have its length. How many of them can be added is limited by the
+// rooms next to start that are still free.
+func (s *groupSearch) bound(used []bool, group []int, next int) int {
+	pathLengths := make([]int, 0, len(group)+1)
+	for _, pathIndex := range group {
+		pathLengths = append(pathLengths, s.lengths[pathIndex])
+	}
+	pathLengths = append(pathLengths, s.lengths[next])
+
+	freeStartRooms := 0
+	for _, room := range s.startRooms {
+		if !used[room] {
+			freeStartRooms++
+		}
+	}
+	extraCount := min(len(s.lengths)-next-1, freeStartRooms+s.directAfter[next])
+	return relaxedTime(pathLengths, s.lengths[next], extraCount, s.ants)
+}
+
+// relaxedTime returns the smallest time T for which paths of the given lengths
+// plus extraCount paths of length extraLength can carry all ants, a path of
+// length l carrying T-l+1 ants by time T.
+func relaxedTime(pathLengths []int, extraLength, extraCount, ants int) int {
+	capacity := func(time int) int {
+		total := extraCount * max(0, time-extraLength+1)
+		for _, length := range pathLengths {
+			total += max(0, time-length+1)
+		}
+		return total
+	}
+	low, high := 0, pathLengths[0]+ants-1
+	for low < high {
+		middle := (low + high) / 2
+		if capacity(middle) >= ants {
+			high = middle
+		} else {
+			low = middle + 1
+		}
+	}
+	return low
+}
@@ -0,0 +1,328 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// IncrementalSolver keeps a farm and a flow of paths that do not share rooms,
+// so the farm can be edited one room or tunnel at a time without solving it
+// again from scratch. Edits keep the flow valid, only the paths going through
+// a removed tunnel or room are dropped, and Solve starts from what is left.
+type IncrementalSolver struct {
+	farm Farm
+	flow map[[2]string]bool // tunnels used by the paths, from -> to
+}
+
+func NewIncrementalSolver(farm Farm) *IncrementalSolver {
+	solver := &IncrementalSolver{farm: farm, flow: make(map[[2]string]bool)}
+	solver.farm.Rooms = append([]Room(nil), farm.Rooms...)
+	solver.farm.Tunnels = append([]Tunnel(nil), farm.Tunnels...)
+	solver.farm.Graph = Graph{Vertices: len(farm.Rooms), Edges: make(map[string][]string)}
+	for roomName, neighbors := range farm.Graph.Edges {
+		solver.farm.Graph.Edges[roomName] = append([]string(nil), neighbors...)
+	}
+	return solver
+}
+
+// Clone returns a solver with a copy of the farm and of the flow, edits to one
+// do not change the other
+func (s *IncrementalSolver) Clone() *IncrementalSolver {
+	clone := NewIncrementalSolver(s.farm)
+	for arc := range s.flow {
+		clone.flow[arc] = true
+	}
+	return clone
+}
+
+// Farm returns the farm with every edit applied
+func (s *IncrementalSolver) Farm() Farm {
+	return s.farm
+}
+
+func (s *IncrementalSolver) AddRoom(room Room) error {
+	if room.IsStart || room.IsEnd {
+		return errors.New("ERROR: start and end rooms can not be added")
+	}
+	if FindRoom(room.Name, s.farm.Rooms) != -1 {
+		return errors.New("ERROR: invalid data format, invalid room format, duplicate room names")
+	}
+	s.farm.Rooms = append(s.farm.Rooms, room)
+	s.farm.Graph.Vertices++
+	return nil
+}
+
+func (s *IncrementalSolver) RemoveRoom(roomName string) error {
+	roomIndex := FindRoom(roomName, s.farm.Rooms)
+	if roomIndex == -1 {
+		return errors.New("ERROR: unknown room " + roomName)
+	}
+	if s.farm.Rooms[roomIndex].IsStart || s.farm.Rooms[roomIndex].IsEnd {
+		return errors.New("ERROR: start and end rooms can not be removed")
+	}
+	for len(s.tunnelsOf(roomName)) > 0 {
+		tunnel := s.farm.Tunnels[s.tunnelsOf(roomName)[0]]
+		s.RemoveTunnel(tunnel.FromRoom.Name, tunnel.ToRoom.Name)
+	}
+	s.farm.Rooms = append(s.farm.Rooms[:roomIndex], s.farm.Rooms[roomIndex+1:]...)
+	s.farm.Graph.Vertices--
+	delete(s.farm.Graph.Edges, roomName)
+	return nil
+}
+
+func (s *IncrementalSolver) AddTunnel(from, to string, directed bool) error {
+	fromIndex, toIndex := FindRoom(from, s.farm.Rooms), FindRoom(to, s.farm.Rooms)
+	if fromIndex == -1 || toIndex == -1 {
+		return errors.New("ERROR: invalid data format, invalid tunnel format")
+	}
+	s.farm.Tunnels = append(s.farm.Tunnels, Tunnel{FromRoom: s.farm.Rooms[fromIndex], ToRoom: s.farm.Rooms[toIndex], Directed: directed})
+	if directed {
+		s.farm.Graph.AddArc(from, to)
+	} else {
+		s.farm.Graph.AddEdge(from, to)
+	}
+	return nil
+}
+
+// RemoveTunnel removes one tunnel between from and to, whatever its direction
+func (s *IncrementalSolver) RemoveTunnel(from, to string) error {
+	for _, tunnelIndex := range s.tunnelsOf(from) {
+		tunnel := s.farm.Tunnels[tunnelIndex]
+		if tunnel.FromRoom.Name != to && tunnel.ToRoom.Name != to {
+			continue
+		}
+		s.farm.Tunnels = append(s.farm.Tunnels[:tunnelIndex], s.farm.Tunnels[tunnelIndex+1:]...)
+		s.removeArc(tunnel.FromRoom.Name, tunnel.ToRoom.Name)
+		if !tunnel.Directed {
+			s.removeArc(tunnel.ToRoom.Name, tunnel.FromRoom.Name)
+		}
+		return nil
+	}
+	return errors.New("ERROR: unknown tunnel " + from + "-" + to)
+}
+
+func (s *IncrementalSolver) SetAnts(numberOfAnts int) error {
+	if numberOfAnts < 1 {
+		return errors.New("ERROR: invalid data format, invalid number of Ants")
+	}
+	s.farm.NumberOfAnts = numberOfAnts
+	return nil
+}
+
+func (s *IncrementalSolver) tunnelsOf(roomName string) []int {
+	var tunnelIndexes []int
+	for i, tunnel := range s.farm.Tunnels {
+		if tunnel.FromRoom.Name == roomName || tunnel.ToRoom.Name == roomName {
+			tunnelIndexes = append(tunnelIndexes, i)
+		}
+	}
+	return tunnelIndexes
+}
+
+// removeArc removes one from -> to edge of the graph and, when a path of the
+// flow used it, the whole path.
+func (s *IncrementalSolver) removeArc(from, to string) {
+	neighbors := s.farm.Graph.Edges[from]
+	for i, neighborName := range neighbors {
+		if neighborName == to {
+			s.farm.Graph.Edges[from] = append(neighbors[:i:i], neighbors[i+1:]...)
+			break
+		}
+	}
+	if containsRoomName(s.farm.Graph.Edges[from], to) || !s.flow[[2]string{from, to}] {
+		return
+	}
+
+	// Walk the path back to start and forward to end, rooms carry one path only
+	delete(s.flow, [2]string{from, to})
+	for roomName := from; roomName != s.farm.Start.Name; {
+		previous, found := s.flowInto(roomName)
+		if !found {
+			break
+		}
+		delete(s.flow, [2]string{previous, roomName})
+		roomName = previous
+	}
+	for roomName := to; roomName != s.farm.End.Name; {
+		next, found := s.flowOutOf(roomName)
+		if !found {
+			break
+		}
+		delete(s.flow, [2]string{roomName, next})
+		roomName = next
+	}
+}
+
+func (s *IncrementalSolver) flowInto(roomName string) (string, bool) {
+	for arc := range s.flow {
+		if arc[1] == roomName {
+			return arc[0], true
+		}
+	}
+	return "", false
+}
+
+func (s *IncrementalSolver) flowOutOf(roomName string) (string, bool) {
+	for arc := range s.flow {
+		if arc[0] == roomName {
+			return arc[1], true
+		}
+	}
+	return "", false
+}
+
+// Solve puts back the flow kept from the previous call, makes it the
+// cheapest flow with as many paths, and moves it one path at a time: the
+// cheapest flow of k paths plus the cheapest path of the residual network is
+// the cheapest flow of k+1 paths, and taking back the most expensive path
+// gives the cheapest flow of k-1. The cheapest k paths take the fewest turns
+// of any k paths, so the best of those flows is the answer. When ctx is done
+// the best flow found so far is used.
+func (s *IncrementalSolver) Solve(ctx context.Context) (Result, error) {
+	farm := s.farm
+	limit := len(farm.Rooms) + len(farm.Tunnels)
+	network, source, sink := newRoomNetwork(farm.Graph, farm.Rooms, farm.Start, farm.End, limit)
+	network.stop = newStopChecker(ctx)
+	roomIndex := make(map[string]int, len(farm.Rooms))
+	for i, room := range farm.Rooms {
+		roomIndex[room.Name] = i
+	}
+	// Every tunnel costs one turn, going through a room costs nothing
+	for node := 1; node < len(network.adj); node += 2 {
+		for index, edge := range network.adj[node] {
+			if edge.capacity > 0 {
+				network.setCost(node, index, 1)
+			}
+		}
+	}
+
+	// Put back the previous flow, edits may have made it more expensive than
+	// needed
+	for arc := range s.flow {
+		from, to := roomIndex[arc[0]], roomIndex[arc[1]]
+		if arc[0] == farm.Start.Name {
+			network.pushEdge(2*from, 2*from+1)
+		}
+		network.pushEdge(2*from+1, 2*to)
+		network.pushEdge(2*to, 2*to+1)
+	}
+	for cycle := network.negativeCycle(); cycle != nil; cycle = network.negativeCycle() {
+		network.augment(cycle)
+	}
+
+	var groups [][][]Room
+	paths := s.readFlow(network)
+	if len(paths) > 0 {
+		groups = append(groups, paths)
+	}
+	saved := network.capacities()
+	for count := len(paths) - 1; count > 0; count-- {
+		path := network.cheapestPath(sink, source)
+		if path == nil {
+			break
+		}
+		network.augment(path)
+		groups = append(groups, s.readFlow(network))
+	}
+	network.restoreCapacities(saved)
+	// More paths than ants can not help
+	for count := len(paths); count < farm.NumberOfAnts; count++ {
+		path := network.cheapestPath(source, sink)
+		if path == nil {
+			break
+		}
+		network.augment(path)
+		groups = append(groups, s.readFlow(network))
+	}
+	// Keep the biggest flow for the next call
+	s.readFlow(network)
+
+	if len(groups) == 0 {
+		if ctx.Err() != nil {
+			return Result{}, ctx.Err()
+		}
+		return Result{}, errors.New("ERROR: invalid data format, end unreachable from start")
+	}
+	for _, group := range groups {
+		sort.Sort(PathSlice(group))
+	}
+	bestPathGroupNames := FindBestPathGroup(groups, farm.NumberOfAnts)
+	solutions := MakeAntsQueue(bestPathGroupNames, farm.NumberOfAnts)
+	turns := SimulateAnts(solutions, bestPathGroupNames, farm.Rooms, farm.NumberOfAnts, farm.End)
+	return Result{Paths: bestPathGroupNames, Turns: turns}, nil
+}
+
+// readFlow keeps the flow of network as the tunnels of s.flow and returns its
+// paths
+func (s *IncrementalSolver) readFlow(network *flowNetwork) [][]Room {
+	farm := s.farm
+	// Flow going back into start or out of end is a loop no ant would take
+	s.flow = make(map[[2]string]bool)
+	for from, room := range farm.Rooms {
+		if room.Name == farm.End.Name {
+			continue
+		}
+		for index, edge := range network.adj[2*from+1] {
+			if edge.to%2 != 0 || edge.to/2 == from || farm.Rooms[edge.to/2].Name == farm.Start.Name {
+				continue
+			}
+			if network.flow(2*from+1, index) > 0 {
+				s.flow[[2]string{room.Name, farm.Rooms[edge.to/2].Name}] = true
+			}
+		}
+	}
+	// Two paths crossing the same tunnel both ways cancel out
+	for arc := range s.flow {
+		if s.flow[[2]string{arc[1], arc[0]}] {
+			delete(s.flow, arc)
+			delete(s.flow, [2]string{arc[1], arc[0]})
+		}
+	}
+	return s.flowPaths()
+}
+
+// flowPaths follows the flow from start to end, loops not going through start
+// are dropped from the flow.
+func (s *IncrementalSolver) flowPaths() [][]Room {
+	roomIndex := make(map[string]int, len(s.farm.Rooms))
+	for i, room := range s.farm.Rooms {
+		roomIndex[room.Name] = i
+	}
+	next := make(map[string][]string)
+	for arc := range s.flow {
+		next[arc[0]] = append(next[arc[0]], arc[1])
+	}
+	for roomName := range next {
+		sort.Strings(next[roomName])
+	}
+
+	used := make(map[[2]string]bool)
+	var paths [][]Room
+	for _, first := range next[s.farm.Start.Name] {
+		path := []Room{s.farm.Start}
+		arcs := [][2]string{{s.farm.Start.Name, first}}
+		roomName := first
+		for len(path) <= len(s.farm.Rooms) {
+			path = append(path, s.farm.Rooms[roomIndex[roomName]])
+			if roomName == s.farm.End.Name || len(next[roomName]) == 0 {
+				break
+			}
+			arcs = append(arcs, [2]string{roomName, next[roomName][0]})
+			roomName = next[roomName][0]
+		}
+		if roomName != s.farm.End.Name {
+			continue
+		}
+		for _, arc := range arcs {
+			used[arc] = true
+		}
+		paths = append(paths, path)
+	}
+	for arc := range s.flow {
+		if !used[arc] {
+			delete(s.flow, arc)
+		}
+	}
+	return paths
+}
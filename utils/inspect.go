@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"LemIn/errorHandler"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Inspection holds structural facts about a farm that explain why it is hard
+type Inspection struct {
+	Rooms              int
+	Tunnels            int
+	OneWayTunnels      int
+	Degrees            map[int]int // degree -> number of rooms
+	Components         []int       // number of rooms of each connected component
+	ShortestPath       int         // -1 when end can not be reached
+	DisjointPaths      int
+	CutRooms           []string // a minimum set of rooms separating start from end
+	DirectTunnel       bool     // start and end are joined by a tunnel, which no room cut can block
+	ArticulationPoints []string
+	Cycles             int // number of independent cycles
+}
+
+func InspectFarm(farm Farm) Inspection {
+	inspection := Inspection{
+		Rooms:        len(farm.Rooms),
+		Tunnels:      len(farm.Tunnels),
+		Degrees:      make(map[int]int),
+		ShortestPath: shortestDistance(farm.Graph, farm.Start.Name, farm.End.Name),
+	}
+	for _, tunnel := range farm.Tunnels {
+		if tunnel.Directed {
+			inspection.OneWayTunnels++
+		}
+	}
+
+	undirected := newUndirectedGraph(farm.Graph, farm.Rooms)
+	for _, edges := range undirected.adjacency {
+		inspection.Degrees[len(edges)]++
+	}
+	components := undirected.connectedComponents()
+	for _, component := range components {
+		inspection.Components = append(inspection.Components, len(component))
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(inspection.Components)))
+	inspection.Cycles = len(undirected.edges) - len(farm.Rooms) + len(components)
+
+	_, articulation := undirected.biconnectedComponents()
+	for i, isArticulation := range articulation {
+		if isArticulation {
+			inspection.ArticulationPoints = append(inspection.ArticulationPoints, farm.Rooms[i].Name)
+		}
+	}
+
+	// Max flow with rooms of capacity one, its minimum cut gives the rooms
+	// every group of paths has to squeeze through
+	limit := len(farm.Rooms) + len(farm.Tunnels)
+	network, source, sink := newRoomNetwork(farm.Graph, farm.Rooms, farm.Start, farm.End, limit)
+	inspection.DisjointPaths = network.maxFlow(source, sink, limit)
+	reachable := network.reachable(source)
+	for i, room := range farm.Rooms {
+		if room.Name != farm.Start.Name && room.Name != farm.End.Name && reachable[2*i] && !reachable[2*i+1] {
+			inspection.CutRooms = append(inspection.CutRooms, room.Name)
+		}
+	}
+	inspection.DirectTunnel = containsRoomName(farm.Graph.Edges[farm.Start.Name], farm.End.Name)
+
+	return inspection
+}
+
+func PrintInspection(inspection Inspection) {
+	fmt.Println("rooms:", inspection.Rooms)
+	fmt.Println("tunnels:", inspection.Tunnels, fmt.Sprintf("(%d one-way)", inspection.OneWayTunnels))
+
+	var degrees []int
+	for degree := range inspection.Degrees {
+		degrees = append(degrees, degree)
+	}
+	sort.Ints(degrees)
+	var distribution []string
+	for _, degree := range degrees {
+		distribution = append(distribution, fmt.Sprint(degree, ":", inspection.Degrees[degree]))
+	}
+	fmt.Println("degree distribution (degree:rooms):", strings.Join(distribution, " "))
+
+	fmt.Println("connected components:", len(inspection.Components), fmt.Sprint("(rooms ", strings.Trim(fmt.Sprint(inspection.Components), "[]"), ")"))
+	if inspection.ShortestPath == -1 {
+		fmt.Println("shortest start->end distance: unreachable")
+	} else {
+		fmt.Println("shortest start->end distance:", inspection.ShortestPath)
+	}
+	fmt.Println("max vertex-disjoint paths:", inspection.DisjointPaths)
+	cut := strings.Join(inspection.CutRooms, " ")
+	if inspection.DirectTunnel {
+		cut = strings.TrimSpace(cut + " (and the start-end tunnel)")
+	}
+	fmt.Println(strings.TrimSpace("min vertex cut: " + cut))
+	fmt.Println(strings.TrimSpace(fmt.Sprint("articulation points: ", len(inspection.ArticulationPoints), " ", strings.Join(inspection.ArticulationPoints, " "))))
+	fmt.Println("independent cycles:", inspection.Cycles)
+}
+
+// Inspect prints the structure of the farm given on the command line
+func Inspect(options Options) {
+	farm, err := ReadFarm(options.FileName)
+	if err != nil {
+		errorHandler.CheckError(err, true)
+		return
+	}
+	PrintInspection(InspectFarm(farm))
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"LemIn/errorHandler"
+	"encoding/json"
+	"os"
+)
+
+// JSONOutput is the solution printed with --json
+type JSONOutput struct {
+	Ants  int        `json:"ants"`
+	Paths [][]string `json:"paths"`
+	Turns [][]string `json:"turns"`
+	Stats Stats      `json:"stats"`
+
+	Explanation *Explanation `json:"explanation,omitempty"`
+	Traces      []AntTrace   `json:"traces,omitempty"`
+	Usage       *UsageReport `json:"usage,omitempty"`
+
+	Alternatives []JSONOutput `json:"alternatives,omitempty"`
+}
+
+func MakeJSONOutput(numberOfAnts int, paths [][]string, turns [][]Move, stats Stats) JSONOutput {
+	output := JSONOutput{Ants: numberOfAnts, Paths: paths, Turns: make([][]string, len(turns)), Stats: stats}
+	for turnIndex, moves := range turns {
+		output.Turns[turnIndex] = make([]string, len(moves))
+		for moveIndex, move := range moves {
+			output.Turns[turnIndex][moveIndex] = move.String()
+		}
+	}
+	return output
+}
+
+func PrintJSON(output JSONOutput) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	errorHandler.CheckError(encoder.Encode(output), true)
+}
@@ -0,0 +1,263 @@
+package utils
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// KShortestPaths returns the k shortest simple paths from start to end in
+// increasing length with Yen's algorithm, in the same form as FindAllPaths.
+// Neighbors are visited in the order of their names and candidates of the same
+// length are taken in the order of ComparePaths, so the paths do not depend on
+// the order of the tunnels in the file. When ctx is done the paths found so
+// far are returned.
+func KShortestPaths(ctx context.Context, graph Graph, start, end Room, rooms []Room, k int) ([][]Room, error) {
+	return kShortestPaths(ctx, graph, start, end, rooms, k, nil)
+}
+
+// KShortestGeometricPaths is KShortestPaths where a path is as long as the
+// turns it takes in geometric mode, the sum of the TunnelLength of its tunnels.
+func KShortestGeometricPaths(ctx context.Context, graph Graph, start, end Room, rooms []Room, k int) ([][]Room, error) {
+	return kShortestPaths(ctx, graph, start, end, rooms, k, TunnelLength)
+}
+
+// kShortestPaths is Yen's algorithm where a tunnel is tunnelLength long, or
+// one when tunnelLength is nil
+func kShortestPaths(ctx context.Context, graph Graph, start, end Room, rooms []Room, k int, tunnelLength func(from, to Room) int) ([][]Room, error) {
+	roomIndex := make(map[string]int, len(rooms))
+	for i, room := range rooms {
+		roomIndex[room.Name] = i
+	}
+	neighbors := make([][]int, len(rooms))
+	for i, room := range rooms {
+		for _, neighborName := range graph.Edges[room.Name] {
+			if neighborIndex, exists := roomIndex[neighborName]; exists {
+				neighbors[i] = append(neighbors[i], neighborIndex)
+			}
+		}
+	}
+	for _, roomNeighbors := range neighbors {
+		sort.Slice(roomNeighbors, func(i, j int) bool {
+			return rooms[roomNeighbors[i]].Name < rooms[roomNeighbors[j]].Name
+		})
+	}
+	startIndex, endIndex := roomIndex[start.Name], roomIndex[end.Name]
+
+	spurSearch := bfsPath
+	pathCost := func(path []int) int { return len(path) - 1 }
+	if tunnelLength != nil {
+		lengths := make([][]int, len(rooms))
+		for i, roomNeighbors := range neighbors {
+			for _, neighbor := range roomNeighbors {
+				lengths[i] = append(lengths[i], tunnelLength(rooms[i], rooms[neighbor]))
+			}
+		}
+		spurSearch = func(neighbors [][]int, start, end int, removedRooms map[int]bool, removedArcs map[[2]int]bool) []int {
+			return dijkstraPath(neighbors, lengths, start, end, removedRooms, removedArcs)
+		}
+		pathCost = func(path []int) int {
+			cost := 0
+			for i := 1; i < len(path); i++ {
+				cost += tunnelLength(rooms[path[i-1]], rooms[path[i]])
+			}
+			return cost
+		}
+	}
+
+	first := spurSearch(neighbors, startIndex, endIndex, nil, nil)
+	if first == nil {
+		return nil, errors.New("ERROR: invalid data format, no path found")
+	}
+
+	shortest := [][]int{first}
+	seen := map[string]bool{pathKey(first): true}
+	var candidates [][]int
+
+	for len(shortest) < k && ctx.Err() == nil {
+		previous := shortest[len(shortest)-1]
+		for spur := 0; spur < len(previous)-1; spur++ {
+			root := previous[:spur+1]
+
+			// Do not find again the paths that share this root
+			removedArcs := make(map[[2]int]bool)
+			for _, path := range shortest {
+				if len(path) > spur+1 && equalPaths(path[:spur+1], root) {
+					removedArcs[[2]int{path[spur], path[spur+1]}] = true
+				}
+			}
+			// The root rooms can not be used again by a simple path
+			removedRooms := make(map[int]bool)
+			for _, room := range root[:spur] {
+				removedRooms[room] = true
+			}
+
+			spurPath := spurSearch(neighbors, previous[spur], endIndex, removedRooms, removedArcs)
+			if spurPath == nil {
+				continue
+			}
+			candidate := append(append([]int(nil), root...), spurPath[1:]...)
+			if key := pathKey(candidate); !seen[key] {
+				seen[key] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+
+		sort.Slice(candidates, func(i, j int) bool {
+			if costI, costJ := pathCost(candidates[i]), pathCost(candidates[j]); costI != costJ {
+				return costI < costJ
+			}
+			return compareIndexPaths(candidates[i], candidates[j], rooms) < 0
+		})
+		shortest = append(shortest, candidates[0])
+		candidates = candidates[1:]
+	}
+
+	allPaths := make([][]Room, len(shortest))
+	for i, path := range shortest {
+		for _, room := range path {
+			allPaths[i] = append(allPaths[i], rooms[room])
+		}
+	}
+	return allPaths, nil
+}
+
+// AdaptivePathLimit chooses how many shortest paths are grouped for a farm. A
+// group never has more paths than there are disjoint paths or ants, so two
+// candidates are kept for each of them. Some spares let a group get around
+// shortest paths sharing rooms, few ants need few of them: one ant only ever
+// takes the shortest path. More makes the group search much slower on dense
+// farms for little gain.
+func AdaptivePathLimit(farm Farm) int {
+	disjointPaths := MaxDisjointPaths(farm.Graph, farm.Rooms, farm.Start, farm.End, farm.NumberOfAnts)
+	return max(min(16, 2*farm.NumberOfAnts), 2*disjointPaths)
+}
+
+// bfsPath returns a shortest path from start to end avoiding removedRooms and
+// removedArcs, or nil when there is none.
+func bfsPath(neighbors [][]int, start, end int, removedRooms map[int]bool, removedArcs map[[2]int]bool) []int {
+	previous := make([]int, len(neighbors))
+	for i := range previous {
+		previous[i] = -1
+	}
+	previous[start] = start
+	queue := []int{start}
+	for len(queue) > 0 {
+		room := queue[0]
+		queue = queue[1:]
+		if room == end {
+			var path []int
+			for ; room != start; room = previous[room] {
+				path = append(path, room)
+			}
+			path = append(path, start)
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+		for _, neighbor := range neighbors[room] {
+			if previous[neighbor] != -1 || removedRooms[neighbor] || removedArcs[[2]int{room, neighbor}] {
+				continue
+			}
+			previous[neighbor] = room
+			queue = append(queue, neighbor)
+		}
+	}
+	return nil
+}
+
+// dijkstraPath is bfsPath where the tunnel to neighbors[room][i] is
+// lengths[room][i] long
+func dijkstraPath(neighbors, lengths [][]int, start, end int, removedRooms map[int]bool, removedArcs map[[2]int]bool) []int {
+	distance := make([]int, len(neighbors))
+	previous := make([]int, len(neighbors))
+	for i := range distance {
+		distance[i] = math.MaxInt
+		previous[i] = -1
+	}
+	distance[start] = 0
+	queue := &roomQueue{{room: start}}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(queuedRoom)
+		room := current.room
+		if current.distance > distance[room] {
+			continue
+		}
+		if room == end {
+			var path []int
+			for ; room != start; room = previous[room] {
+				path = append(path, room)
+			}
+			path = append(path, start)
+			slices.Reverse(path)
+			return path
+		}
+		for i, neighbor := range neighbors[room] {
+			if removedRooms[neighbor] || removedArcs[[2]int{room, neighbor}] {
+				continue
+			}
+			if next := distance[room] + lengths[room][i]; next < distance[neighbor] {
+				distance[neighbor] = next
+				previous[neighbor] = room
+				heap.Push(queue, queuedRoom{room: neighbor, distance: next})
+			}
+		}
+	}
+	return nil
+}
+
+type queuedRoom struct {
+	room, distance int
+}
+
+// roomQueue is a heap of rooms, the closest first
+type roomQueue []queuedRoom
+
+func (q roomQueue) Len() int           { return len(q) }
+func (q roomQueue) Less(i, j int) bool { return q[i].distance < q[j].distance }
+func (q roomQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+func (q *roomQueue) Push(x any)        { *q = append(*q, x.(queuedRoom)) }
+func (q *roomQueue) Pop() any {
+	old := *q
+	room := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return room
+}
+
+func equalPaths(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func pathKey(path []int) string {
+	return fmt.Sprint(path)
+}
+
+// compareIndexPaths is ComparePaths for paths given as indexes in rooms
+func compareIndexPaths(a, b []int, rooms []Room) int {
+	if len(a) != len(b) {
+		return len(a) - len(b)
+	}
+	for i := range a {
+		if rooms[a[i]].Name != rooms[b[i]].Name {
+			return strings.Compare(rooms[a[i]].Name, rooms[b[i]].Name)
+		}
+	}
+	return 0
+}
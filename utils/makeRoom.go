@@ -8,28 +8,35 @@ import (
 )
 
 func MakeRoom(rowData string) Room {
+	room, err := ParseRoom(rowData)
+	if err != nil {
+		errorHandler.CheckError(err, true)
+		return Room{}
+	}
+	return room
+}
+
+// ParseRoom is MakeRoom returning the error instead of exiting
+func ParseRoom(rowData string) (Room, error) {
 	rowDataSplited := strings.Split(rowData, " ")
 	if len(rowDataSplited) != 3 {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, invalid room format"), true)
-		return Room{}
+		return Room{}, errors.New("ERROR: invalid data format, invalid room format")
 	}
 
 	roomName := rowDataSplited[0]
-	if strings.HasPrefix(roomName, "#") || strings.HasPrefix(roomName, "L") {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, invalid room format"), true)
-		return Room{}
+	if roomName == "" || strings.HasPrefix(roomName, "#") || strings.HasPrefix(roomName, "L") || strings.ContainsAny(roomName, "->") {
+		return Room{}, errors.New("ERROR: invalid data format, invalid room format")
 	}
 
 	coord_x, err_x := strconv.Atoi(rowDataSplited[1])
 	coord_y, err_y := strconv.Atoi(rowDataSplited[2])
 	if err_x != nil || err_y != nil {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, invalid room format"), true)
-		return Room{}
+		return Room{}, errors.New("ERROR: invalid data format, invalid room format")
 	}
 
 	return Room{
 		Name:    roomName,
 		Coord_x: coord_x,
 		Coord_y: coord_y,
-	}
+	}, nil
 }
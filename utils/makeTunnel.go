@@ -7,25 +7,35 @@ import (
 )
 
 func MakeTunnel(rowData string, rooms []Room) Tunnel {
-	rowDataSplited := strings.Split(rowData, "-")
-
-	if len(rowDataSplited) != 2 {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, invalid tunnel format"), true)
+	tunnel, err := ParseTunnel(rowData, rooms)
+	if err != nil {
+		errorHandler.CheckError(err, true)
 		return Tunnel{}
 	}
+	return tunnel
+}
+
+// ParseTunnel is MakeTunnel returning the error instead of exiting
+func ParseTunnel(rowData string, rooms []Room) (Tunnel, error) {
+	separator := tunnelSeparator(rowData)
+	rowDataSplited := strings.Split(rowData, separator)
+
+	if len(rowDataSplited) != 2 || (separator == ">" && strings.Contains(rowData, "-")) {
+		return Tunnel{}, errors.New("ERROR: invalid data format, invalid tunnel format")
+	}
 
 	firstRoomIndex := FindRoom(rowDataSplited[0], rooms)
 	secondRoomIndex := FindRoom(rowDataSplited[1], rooms)
 
 	if secondRoomIndex == -1 || firstRoomIndex == -1 {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, invalid tunnel format"), true)
-		return Tunnel{}
+		return Tunnel{}, errors.New("ERROR: invalid data format, invalid tunnel format")
 	}
 
 	return Tunnel{
 		FromRoom: rooms[firstRoomIndex],
 		ToRoom:   rooms[secondRoomIndex],
-	}
+		Directed: separator == ">",
+	}, nil
 }
 
 func FindRoom(roomName string, rooms []Room) int {
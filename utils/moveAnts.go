@@ -3,28 +3,67 @@ package utils
 import "fmt"
 
 func MoveAnts(solutions []Solution, pathsNames [][]string, rooms []Room, numberOfAnts int, end Room) {
+	PrintMoves(SimulateAnts(solutions, pathsNames, rooms, numberOfAnts, end), end)
+}
+
+// SimulateAnts moves the ants of each solution along its path and returns the
+// moves made in every turn.
+func SimulateAnts(solutions []Solution, pathsNames [][]string, rooms []Room, numberOfAnts int, end Room) [][]Move {
 	paths := changeTypeOfPaths(pathsNames, rooms)
 
 	numberOfAntsNotReachedToEnd := numberOfAnts
-	turnNumber := 0
+	var turns [][]Move
 
 	for numberOfAntsNotReachedToEnd > 0 {
-		turnNumber++
-		fmt.Print("turn ", turnNumber, ": ")
+		var moves []Move
 
 		for _, solution := range solutions {
 			for antIndex, ant := range solution.Ants {
-				stepForward(&solution.Ants[antIndex], solution, paths, end, &numberOfAntsNotReachedToEnd)
+				stepForward(&solution.Ants[antIndex], solution, paths, end, &numberOfAntsNotReachedToEnd, &moves)
 				if ant.CurrentRoomName == "" {
 					break
 				}
 			}
 
 		}
+		turns = append(turns, moves)
+	}
+	return turns
+}
+
+// PrintMoves prints the moves of every turn, ants reaching the end room are
+// highlighted.
+func PrintMoves(turns [][]Move, end Room) {
+	bgYellow := "\033[43m"
+	reset := "\033[0m"
+
+	for turnIndex, moves := range turns {
+		fmt.Print("turn ", turnIndex+1, ": ")
+		for _, move := range moves {
+			if move.RoomName == end.Name && !move.InTransit() {
+				fmt.Print(bgYellow, move, reset, " ")
+			} else {
+				fmt.Print(move, " ")
+			}
+		}
 		fmt.Println()
 	}
 }
 
+// InTransit tells if the ant is still inside a tunnel at the end of the turn
+func (m Move) InTransit() bool {
+	return m.Steps > 0
+}
+
+// String writes the move as L1-room, or L1-from>room:1/3 for an ant that walked
+// one of the three turns of a tunnel.
+func (m Move) String() string {
+	if m.InTransit() {
+		return fmt.Sprint("L", m.AntId, "-", m.From, ">", m.RoomName, ":", m.Step, "/", m.Steps)
+	}
+	return fmt.Sprint("L", m.AntId, "-", m.RoomName)
+}
+
 func changeTypeOfPaths(paths [][]string, rooms []Room) [][]Room {
 	var output = make([][]Room, len(paths))
 	for pathIndex, path := range paths {
@@ -39,10 +78,7 @@ func changeTypeOfPaths(paths [][]string, rooms []Room) [][]Room {
 	return output
 }
 
-func stepForward(ant *Ant, solution Solution, paths [][]Room, end Room, numberOfAntsNotReachedToEnd *int) {
-	bgYellow := "\033[43m"
-	reset := "\033[0m"
-
+func stepForward(ant *Ant, solution Solution, paths [][]Room, end Room, numberOfAntsNotReachedToEnd *int, moves *[]Move) {
 	antCurrentRoomName := ant.CurrentRoomName
 	if antCurrentRoomName != end.Name {
 		for i, v := range paths[solution.PathIndex] {
@@ -56,12 +92,10 @@ func stepForward(ant *Ant, solution Solution, paths [][]Room, end Room, numberOf
 		}
 		if !ant.HasReachedTheEnd {
 			if ant.CurrentRoomName == end.Name {
-				fmt.Print(bgYellow, "L", ant.Id, "-", ant.CurrentRoomName, reset, " ")
 				ant.HasReachedTheEnd = true
 				*numberOfAntsNotReachedToEnd--
-			} else {
-				fmt.Print("L", ant.Id, "-", ant.CurrentRoomName, " ")
 			}
+			*moves = append(*moves, Move{AntId: ant.Id, RoomName: ant.CurrentRoomName})
 		}
 	}
 }
@@ -0,0 +1,92 @@
+package utils
+
+import (
+	"cmp"
+	"errors"
+	"strings"
+)
+
+// Criterion is one value a group of paths is judged on
+type Criterion int
+
+const (
+	// CriterionTurns is the turns until the last ant arrives
+	CriterionTurns Criterion = iota
+	// CriterionMoves is the number of moves of all the ants
+	CriterionMoves
+	// CriterionArrivals is the sum of the turns every ant arrives at
+	CriterionArrivals
+	// CriterionRooms is the number of rooms the ants go through
+	CriterionRooms
+)
+
+var criterionNames = []string{"turns", "moves", "arrivals", "rooms"}
+
+// Objective is the order the criteria are compared in, a group of paths
+// is better than another on the first criterion where they differ. It always
+// starts with turns, the group search cuts its branches on them. Groups equal
+// on every criterion come in the order of ComparePathGroups.
+type Objective []Criterion
+
+// DefaultObjective only looks at the turns
+var DefaultObjective = Objective{CriterionTurns}
+
+// ParseObjective reads the --objective value, like turns,moves,rooms
+func ParseObjective(value string) (Objective, error) {
+	var objective Objective
+	for _, name := range strings.Split(value, ",") {
+		criterion := -1
+		for i, criterionName := range criterionNames {
+			if strings.TrimSpace(name) == criterionName {
+				criterion = i
+			}
+		}
+		if criterion == -1 {
+			return nil, errors.New("ERROR: invalid --objective value " + value + ", use turns, moves, arrivals and rooms")
+		}
+		objective = append(objective, Criterion(criterion))
+	}
+	if objective[0] != CriterionTurns {
+		return nil, errors.New("ERROR: invalid --objective value " + value + ", it must start with turns")
+	}
+	return objective, nil
+}
+
+func (o Objective) String() string {
+	names := make([]string, len(o))
+	for i, criterion := range o {
+		names[i] = criterionNames[criterion]
+	}
+	return strings.Join(names, ",")
+}
+
+// GroupScore holds the criteria of a group of paths
+type GroupScore [4]int
+
+// scoreGroup scores a group from the lengths of its paths, measured like the
+// group search does, the number of rooms of each path and how the ants are
+// split. Moves and arrivals are off by the same constant for every group.
+func scoreGroup(lengths, rooms, ants []int) GroupScore {
+	var score GroupScore
+	for i, length := range lengths {
+		if ants[i] == 0 {
+			continue
+		}
+		score[CriterionTurns] = max(score[CriterionTurns], length+ants[i]-1)
+		score[CriterionMoves] += ants[i] * length
+		score[CriterionArrivals] += ants[i]*length + ants[i]*(ants[i]-1)/2
+		score[CriterionRooms] += rooms[i]
+	}
+	return score
+}
+
+// compare returns -1 when a is better than b, 1 when it is worse and 0 when
+// they are equal on every criterion of the objective
+func (o Objective) compare(a, b GroupScore) int {
+	for _, criterion := range o {
+		if order := cmp.Compare(a[criterion], b[criterion]); order != 0 {
+			return order
+		}
+	}
+	return 0
+}
@@ -2,13 +2,28 @@ package utils
 
 import (
 	"LemIn/errorHandler"
+	"cmp"
+	"context"
 	"errors"
+	"strings"
 )
 
 // ExtractAllPaths extracts all paths from start to end
 func ExtractAllPaths(graph Graph, start, end Room, rooms []Room) [][]Room {
+	allPaths, err := FindAllPaths(context.Background(), graph, start, end, rooms)
+	if err != nil {
+		errorHandler.CheckError(err, true)
+		return nil
+	}
+	return allPaths
+}
+
+// FindAllPaths is ExtractAllPaths returning the error instead of exiting.
+// When ctx is done the paths found so far are returned.
+func FindAllPaths(ctx context.Context, graph Graph, start, end Room, rooms []Room) ([][]Room, error) {
 	var allPaths [][]Room
 	var currentPath []Room
+	stop := newStopChecker(ctx)
 
 	// DFS to find all paths from start to end
 	var dfs func(node Room, visited map[string]bool)
@@ -21,6 +36,9 @@ func ExtractAllPaths(graph Graph, start, end Room, rooms []Room) [][]Room {
 
 		visited[node.Name] = true
 		for _, neighborName := range graph.Edges[node.Name] {
+			if stop.stopped() {
+				break
+			}
 			if !visited[neighborName] {
 				neighborIndex := FindRoom(neighborName, rooms)
 				neighbor := rooms[neighborIndex] // Assuming Room can be reconstructed from name
@@ -37,15 +55,24 @@ func ExtractAllPaths(graph Graph, start, end Room, rooms []Room) [][]Room {
 	dfs(start, make(map[string]bool))
 
 	if len(allPaths) < 1 {
-		errorHandler.CheckError(errors.New("ERROR: invalid data format, no path found"), true)
-		return nil
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, errors.New("ERROR: invalid data format, no path found")
 	}
 
-	return allPaths
+	return allPaths, nil
 }
 
 func FilterNonIntersectingGroups(allPaths [][]Room) [][][]Room {
+	return FilterNonIntersectingGroupsContext(context.Background(), allPaths)
+}
+
+// FilterNonIntersectingGroupsContext returns the groups found so far when ctx
+// is done.
+func FilterNonIntersectingGroupsContext(ctx context.Context, allPaths [][]Room) [][][]Room {
 	var result [][][]Room
+	stop := newStopChecker(ctx)
 
 	// Helper function to check if a path can be added to the group
 	canAddPath := func(group [][]Room, path []Room) bool {
@@ -77,7 +104,7 @@ func FilterNonIntersectingGroups(allPaths [][]Room) [][][]Room {
 		}
 
 		// Iterate over remaining paths
-		for i := start; i < len(allPaths); i++ {
+		for i := start; i < len(allPaths) && !stop.stopped(); i++ {
 			if canAddPath(group, allPaths[i]) {
 				// Add path to the group
 				group = append(group, allPaths[i])
@@ -95,6 +122,14 @@ func FilterNonIntersectingGroups(allPaths [][]Room) [][][]Room {
 }
 
 func RemoveSmallerGroups(groups [][][]Room) [][][]Room {
+	return RemoveSmallerGroupsContext(context.Background(), groups)
+}
+
+// RemoveSmallerGroupsContext keeps the groups it had no time to check when
+// ctx is done, they are still valid groups.
+func RemoveSmallerGroupsContext(ctx context.Context, groups [][][]Room) [][][]Room {
+	stop := newStopChecker(ctx)
+
 	// Helper function to check if all paths in group1 exist in group2
 	containsAllPaths := func(group1, group2 [][]Room) bool {
 		pathSet := make(map[string]bool)
@@ -121,6 +156,9 @@ func RemoveSmallerGroups(groups [][][]Room) [][][]Room {
 	// Filter groups
 	var result [][][]Room
 	for i := 0; i < len(groups); i++ {
+		if stop.stopped() {
+			return append(result, groups[i:]...)
+		}
 		include := true
 		for j := 0; j < len(groups); j++ {
 			if i != j && len(groups[j]) > len(groups[i]) && containsAllPaths(groups[i], groups[j]) {
@@ -150,24 +188,38 @@ func FindBestPathGroup(groups [][][]Room, ants int) [][]string {
 		numAnts := assignAntsToPaths(pathLengths, ants)
 
 		// Calculate the time for this group which is equal to the longest time between all paths of group
+		// that get ants
 		maxTime := 0
 		for i, length := range pathLengths {
+			if numAnts[i] == 0 {
+				continue
+			}
 			time := length + numAnts[i] - 1
 			if time > maxTime {
 				maxTime = time
 			}
 		}
 
-		// Update the best group if this one is faster
-		if maxTime < minTime {
+		// Update the best group if this one is faster, or as fast and first in
+		// the order of ComparePathGroups
+		if maxTime < minTime || maxTime == minTime && ComparePathGroups(group, bestGroup) < 0 {
 			minTime = maxTime
 			bestGroup = group
 		}
 	}
 
-	// Here we found bestGroup but we need names of the rooms in paths of group so we do this
+	// Here we found bestGroup but we need names of the rooms in paths of group
+	// that get ants so we do this
+	bestLengths := make([]int, len(bestGroup))
+	for i, path := range bestGroup {
+		bestLengths[i] = len(path)
+	}
+	shares := assignAntsToPaths(bestLengths, ants)
 	var bestPathGroupNames [][]string
-	for _, path := range bestGroup {
+	for i, path := range bestGroup {
+		if shares[i] == 0 {
+			continue
+		}
 		var pathNames []string
 		for roomIndex, room := range path {
 			if roomIndex != 0 {
@@ -180,6 +232,26 @@ func FindBestPathGroup(groups [][][]Room, ants int) [][]string {
 	return bestPathGroupNames
 }
 
+// PredictTurns returns the number of turns FindBestPathGroup expects for a
+// group of paths given without the start room
+func PredictTurns(paths [][]string, ants int) int {
+	if len(paths) == 0 {
+		return 0
+	}
+	pathLengths := make([]int, len(paths))
+	for i, path := range paths {
+		pathLengths[i] = len(path)
+	}
+	numAnts := assignAntsToPaths(pathLengths, ants)
+	turns := 0
+	for i, length := range pathLengths {
+		if numAnts[i] > 0 {
+			turns = max(turns, length+numAnts[i]-1)
+		}
+	}
+	return turns
+}
+
 // Helper function to assign ants to paths
 func assignAntsToPaths(pathLengths []int, ants int) []int {
 	numPaths := len(pathLengths)
@@ -204,3 +276,29 @@ func assignAntsToPaths(pathLengths []int, ants int) []int {
 
 	return numAnts
 }
+
+// ComparePaths orders paths by length, then by the names of their rooms. It is
+// the order every tie between paths is broken with, so the output does not
+// depend on the order of the tunnels in the file.
+func ComparePaths(a, b []Room) int {
+	if len(a) != len(b) {
+		return cmp.Compare(len(a), len(b))
+	}
+	for i := range a {
+		if a[i].Name != b[i].Name {
+			return strings.Compare(a[i].Name, b[i].Name)
+		}
+	}
+	return 0
+}
+
+// ComparePathGroups orders groups of paths sorted with ComparePaths path by
+// path, a group comes before the bigger groups it starts.
+func ComparePathGroups(a, b [][]Room) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if order := ComparePaths(a[i], b[i]); order != 0 {
+			return order
+		}
+	}
+	return cmp.Compare(len(a), len(b))
+}
@@ -0,0 +1,104 @@
+package utils
+
+import "errors"
+
+// PruneGraph removes the rooms that can not be on any simple path from start
+// to end and returns the smaller graph with the number of rooms removed.
+// Rooms that can not be reached from start or can not reach end go first.
+// When all tunnels are two-way, a room is on a simple path from start to end
+// only if it is in the same biconnected component as an extra start-end
+// tunnel, which also removes dead-end chains and rooms behind a single door.
+func PruneGraph(graph Graph, rooms []Room, start, end Room) (Graph, int, error) {
+	reverse := make(map[string][]string)
+	directed := false
+	for from, neighbors := range graph.Edges {
+		for _, to := range neighbors {
+			reverse[to] = append(reverse[to], from)
+			if !containsRoomName(graph.Edges[to], from) {
+				directed = true
+			}
+		}
+	}
+
+	fromStart := reachableRooms(graph.Edges, start.Name)
+	if !fromStart[end.Name] {
+		return graph, 0, errors.New("ERROR: invalid data format, end unreachable from start")
+	}
+	toEnd := reachableRooms(reverse, end.Name)
+
+	keep := make(map[string]bool)
+	for roomName := range fromStart {
+		if toEnd[roomName] {
+			keep[roomName] = true
+		}
+	}
+
+	if !directed {
+		undirected := newUndirectedGraph(graph, rooms)
+		startIndex, endIndex := FindRoom(start.Name, rooms), FindRoom(end.Name, rooms)
+		virtualEdge := undirected.addEdge(startIndex, endIndex)
+		components, _ := undirected.biconnectedComponents()
+		for _, component := range components {
+			if !containsEdge(component, virtualEdge) {
+				continue
+			}
+			inComponent := make(map[string]bool)
+			for _, id := range component {
+				inComponent[rooms[undirected.edges[id][0]].Name] = true
+				inComponent[rooms[undirected.edges[id][1]].Name] = true
+			}
+			for roomName := range keep {
+				if !inComponent[roomName] {
+					delete(keep, roomName)
+				}
+			}
+		}
+	}
+
+	pruned := Graph{Vertices: len(keep), Edges: make(map[string][]string)}
+	for from, neighbors := range graph.Edges {
+		if !keep[from] {
+			continue
+		}
+		for _, to := range neighbors {
+			if keep[to] {
+				pruned.Edges[from] = append(pruned.Edges[from], to)
+			}
+		}
+	}
+	return pruned, len(rooms) - len(keep), nil
+}
+
+func reachableRooms(edges map[string][]string, from string) map[string]bool {
+	seen := map[string]bool{from: true}
+	queue := []string{from}
+	for len(queue) > 0 {
+		roomName := queue[0]
+		queue = queue[1:]
+		for _, neighborName := range edges[roomName] {
+			if !seen[neighborName] {
+				seen[neighborName] = true
+				queue = append(queue, neighborName)
+			}
+		}
+	}
+	return seen
+}
+
+func containsRoomName(roomNames []string, roomName string) bool {
+	for _, name := range roomNames {
+		if name == roomName {
+			return true
+		}
+	}
+	return false
+}
+
+func containsEdge(edges []int, edge int) bool {
+	for _, id := range edges {
+		if id == edge {
+			return true
+		}
+	}
+	return false
+}
@@ -3,13 +3,164 @@ package utils
 import (
 	"LemIn/errorHandler"
 	"errors"
+	"flag"
 	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
 )
 
-func ReadFromCommandLine() string {
+// Options are the settings given on the command line
+type Options struct {
+	Command      string
+	FileName     string
+	Stats        bool
+	JSON         bool
+	Solvers      []string
+	Timeout      time.Duration
+	Jobs         int
+	Paths        int
+	Addr         string
+	Workers      int
+	Report       string
+	Expect       bool
+	Geometric    bool
+	Explain      int
+	Seed         int64
+	TraceAnts    [][2]int // ranges of ants, see ParseAntIds
+	Usage        bool
+	Ants         [2]int // first and last number of ants of lem-in curve
+	SVG          string
+	Alternatives int
+	Objective    Objective
+	// Largest farms lem-in serve solves, 0 means no limit
+	MaxAnts  int
+	MaxRooms int
+	MaxTurns int
+}
+
+// ReadFromCommandLine reads `lem-in [flags] file`, `lem-in bench [flags] dir`,
+// `lem-in batch [flags] dir`, `lem-in inspect file`, `lem-in curve [flags] file`,
+// `lem-in criticality file` or `lem-in serve [flags]`
+func ReadFromCommandLine() Options {
+	var options Options
 	args := os.Args[1:]
-	if len(args) != 1 {
+	if len(args) > 0 && (args[0] == "bench" || args[0] == "batch" || args[0] == "inspect" || args[0] == "curve" || args[0] == "criticality" || args[0] == "serve") {
+		options.Command = args[0]
+		args = args[1:]
+	}
+
+	flags := flag.NewFlagSet("lem-in", flag.ExitOnError)
+	var solverNames, traceAnts, ants, objective string
+	paths := "all"
+	flags.StringVar(&paths, "paths", paths, "paths to group: all, auto (chosen from the farm) or a number k of shortest paths")
+	flags.IntVar(&options.Jobs, "jobs", 1, "number of goroutines searching for the best group of paths")
+	timeout := time.Duration(0)
+	switch options.Command {
+	case "serve":
+		timeout = 30 * time.Second
+	case "batch":
+		timeout = 10 * time.Second
+	case "curve", "criticality":
+		timeout = 30 * time.Second
+	}
+	flags.DurationVar(&options.Timeout, "timeout", timeout, "stop searching after this long and use the best solution found so far, 0 means no limit")
+	switch options.Command {
+	case "inspect", "criticality":
+	case "batch":
+		flags.IntVar(&options.Workers, "workers", runtime.NumCPU(), "number of maps solved at the same time")
+		flags.StringVar(&options.Report, "report", "", "also write the results to this file, as JSON if it ends with .json and CSV otherwise")
+		flags.BoolVar(&options.Expect, "expect", false, "flag maps needing more turns than their .expected file, or accepted when it says error")
+	case "curve":
+		flags.StringVar(&ants, "ants", "", "numbers of ants to solve for, like 1..10000, from 1 to the ants of the file by default")
+		flags.StringVar(&options.SVG, "svg", "", "also draw the curve in this SVG file")
+	case "serve":
+		flags.StringVar(&options.Addr, "addr", ":8080", "address to listen on")
+		flags.IntVar(&options.MaxAnts, "max-ants", 100000, "reject farms with more ants, 0 means no limit")
+		flags.IntVar(&options.MaxRooms, "max-rooms", 10000, "reject farms with more rooms, 0 means no limit")
+		flags.IntVar(&options.MaxTurns, "max-turns", 100000, "reject farms needing more turns than this at least, 0 means no limit")
+	case "bench":
+		flags.StringVar(&solverNames, "solvers", strings.Join(BenchSolverNames(), ","), "comma separated solvers to run on every map")
+	default:
+		flags.BoolVar(&options.Stats, "stats", false, "print the number of turns, the lower bound and the gap after the moves")
+		flags.BoolVar(&options.JSON, "json", false, "print the solution as JSON")
+		flags.BoolVar(&options.Usage, "usage", false, "also print how many ants used each room and tunnel and how many arrived each turn")
+		flags.StringVar(&options.Report, "report", "", "write the usage of rooms, tunnels and turns to this file, as JSON if it ends with .json and CSV otherwise")
+		flags.StringVar(&traceAnts, "trace-ant", "", "also print the route of these ants, like 17, 3-5 or 1,4,10-12")
+		flags.Int64Var(&options.Seed, "seed", 0, "break ties between paths of the same length in a random order given by this seed, 0 sorts them by room names")
+		flags.StringVar(&objective, "objective", "turns", "criteria comparing groups of paths in order, among turns, moves, arrivals and rooms, starting with turns")
+		flags.IntVar(&options.Alternatives, "alternatives", 0, "also print the N next best groups of paths with their moves")
+		flags.IntVar(&options.Explain, "explain", 0, "also show the best N groups of paths compared and why the chosen one won")
+		flags.BoolVar(&options.Geometric, "geometric", false, "tunnels take as many turns as the rounded distance between their rooms")
+	}
+
+	args = parseFlags(flags, args)
+	expectedArgs := 1
+	if options.Command == "serve" {
+		expectedArgs = 0
+	}
+	if len(args) != expectedArgs {
 		errorHandler.CheckError(errors.New("not enough argumnts"), true)
+		return options
+	}
+	if expectedArgs == 1 {
+		options.FileName = args[0]
+	}
+	switch paths {
+	case "all":
+		options.Paths = 0
+	case "auto":
+		options.Paths = AdaptivePaths
+	default:
+		k, err := strconv.Atoi(paths)
+		if err != nil || k < 1 {
+			errorHandler.CheckError(errors.New("invalid --paths value "+paths), true)
+			return options
+		}
+		options.Paths = k
+	}
+	if ants != "" {
+		antsRange, err := ParseAntsRange(ants)
+		if err != nil {
+			errorHandler.CheckError(err, true)
+			return options
+		}
+		options.Ants = antsRange
+	}
+	if objective != "" && objective != "turns" {
+		parsed, err := ParseObjective(objective)
+		if err != nil {
+			errorHandler.CheckError(err, true)
+			return options
+		}
+		options.Objective = parsed
+	}
+	if traceAnts != "" {
+		antRanges, err := ParseAntIds(traceAnts)
+		if err != nil {
+			errorHandler.CheckError(err, true)
+			return options
+		}
+		options.TraceAnts = antRanges
+	}
+	if solverNames != "" {
+		options.Solvers = strings.Split(solverNames, ",")
+	}
+	return options
+}
+
+// parseFlags parses flags placed before or after the arguments and returns the
+// arguments.
+func parseFlags(flags *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		flags.Parse(args)
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
 	}
-	return args[0]
 }
@@ -0,0 +1,205 @@
+package utils
+
+import (
+	"LemIn/errorHandler"
+	"LemIn/fileHandler"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxRequestBytes is the largest request body the server reads
+const maxRequestBytes = 4 << 20
+
+// serveRequest is the JSON body of a request. A body that is not JSON is the
+// farm itself, followed for /verify by an empty line and the moves, like the
+// output of lem-in.
+type serveRequest struct {
+	Farm   string `json:"farm"`
+	Moves  string `json:"moves,omitempty"`
+	Solver string `json:"solver,omitempty"`
+}
+
+type serveSolveResponse struct {
+	JSONOutput
+	TimedOut bool `json:"timed_out"`
+}
+
+type serveValidateResponse struct {
+	Valid   bool   `json:"valid"`
+	Error   string `json:"error,omitempty"`
+	Ants    int    `json:"ants,omitempty"`
+	Rooms   int    `json:"rooms,omitempty"`
+	Tunnels int    `json:"tunnels,omitempty"`
+	Turns   int    `json:"turns,omitempty"`
+}
+
+type serveErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Serve answers solve, validate and verify requests over HTTP until the
+// server fails.
+func Serve(options Options) {
+	server := &http.Server{
+		Addr:              options.Addr,
+		Handler:           NewServeHandler(options),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Println("lem-in serving on", options.Addr)
+	errorHandler.CheckError(server.ListenAndServe(), true)
+}
+
+// NewServeHandler returns the handler of lem-in serve. Each solve stops after
+// options.Timeout and answers with the best solution found so far.
+func NewServeHandler(options Options) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /solve", func(w http.ResponseWriter, r *http.Request) {
+		request, farmContent, _, ok := readServeRequest(w, r)
+		if !ok {
+			return
+		}
+		farm, err := ParseFarm(farmContent)
+		if err != nil {
+			writeServeJSON(w, http.StatusBadRequest, serveErrorResponse{Error: err.Error()})
+			return
+		}
+		if err := checkServeLimits(farm, options); err != nil {
+			writeServeJSON(w, http.StatusRequestEntityTooLarge, serveErrorResponse{Error: err.Error()})
+			return
+		}
+		solverName := request.Solver
+		if solverName == "" {
+			solverName = "paths"
+		}
+		solver, err := GetSolver(solverName)
+		if err != nil {
+			writeServeJSON(w, http.StatusBadRequest, serveErrorResponse{Error: err.Error()})
+			return
+		}
+
+		ctx, cancel := r.Context(), context.CancelFunc(func() {})
+		if options.Timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		}
+		defer cancel()
+		result, err := solver(ctx, farm, WithJobs(options.Jobs), WithPathLimit(options.Paths))
+		if err != nil {
+			status := http.StatusUnprocessableEntity
+			if ctx.Err() != nil {
+				status = http.StatusGatewayTimeout
+			}
+			writeServeJSON(w, status, serveErrorResponse{Error: err.Error()})
+			return
+		}
+		stats := MakeStats(result.Turns, farm.End, LowerBound(farm.Graph, farm.Rooms, farm.Start, farm.End, farm.NumberOfAnts), result.PrunedRooms)
+		writeServeJSON(w, http.StatusOK, serveSolveResponse{
+			JSONOutput: MakeJSONOutput(farm.NumberOfAnts, result.Paths, result.Turns, stats),
+			TimedOut:   ctx.Err() != nil,
+		})
+	})
+
+	mux.HandleFunc("POST /validate", func(w http.ResponseWriter, r *http.Request) {
+		_, farmContent, _, ok := readServeRequest(w, r)
+		if !ok {
+			return
+		}
+		farm, err := ParseFarm(farmContent)
+		if err != nil {
+			writeServeJSON(w, http.StatusOK, serveValidateResponse{Error: err.Error()})
+			return
+		}
+		writeServeJSON(w, http.StatusOK, serveValidateResponse{Valid: true, Ants: farm.NumberOfAnts, Rooms: len(farm.Rooms), Tunnels: len(farm.Tunnels)})
+	})
+
+	mux.HandleFunc("POST /verify", func(w http.ResponseWriter, r *http.Request) {
+		_, farmContent, movesContent, ok := readServeRequest(w, r)
+		if !ok {
+			return
+		}
+		farm, err := ParseFarm(farmContent)
+		if err != nil {
+			writeServeJSON(w, http.StatusBadRequest, serveErrorResponse{Error: err.Error()})
+			return
+		}
+		if err := checkServeLimits(farm, options); err != nil {
+			writeServeJSON(w, http.StatusRequestEntityTooLarge, serveErrorResponse{Error: err.Error()})
+			return
+		}
+		turns, err := ParseTranscript(movesContent)
+		if err == nil {
+			err = VerifyMoves(farm, turns)
+		}
+		if err != nil {
+			writeServeJSON(w, http.StatusOK, serveValidateResponse{Error: err.Error()})
+			return
+		}
+		writeServeJSON(w, http.StatusOK, serveValidateResponse{Valid: true, Turns: len(turns)})
+	})
+	return mux
+}
+
+// checkServeLimits rejects the farms too big to solve in memory, the ants
+// and the moves of every turn are all kept until the answer is written
+func checkServeLimits(farm Farm, options Options) error {
+	if options.MaxAnts > 0 && farm.NumberOfAnts > options.MaxAnts {
+		return fmt.Errorf("ERROR: the farm has %d ants, at most %d are solved", farm.NumberOfAnts, options.MaxAnts)
+	}
+	if options.MaxRooms > 0 && len(farm.Rooms) > options.MaxRooms {
+		return fmt.Errorf("ERROR: the farm has %d rooms, at most %d are solved", len(farm.Rooms), options.MaxRooms)
+	}
+	if options.MaxTurns > 0 {
+		if lowerBound := LowerBound(farm.Graph, farm.Rooms, farm.Start, farm.End, farm.NumberOfAnts); lowerBound > options.MaxTurns {
+			return fmt.Errorf("ERROR: the farm needs at least %d turns, at most %d are solved", lowerBound, options.MaxTurns)
+		}
+	}
+	return nil
+}
+
+// readServeRequest reads the body of a request as the lines of the farm and
+// of the moves. It answers the request itself and returns false when the body
+// can not be read.
+func readServeRequest(w http.ResponseWriter, r *http.Request) (serveRequest, []string, []string, bool) {
+	var request serveRequest
+	body := http.MaxBytesReader(w, r.Body, maxRequestBytes)
+	var lines []string
+	var err error
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		err = json.NewDecoder(body).Decode(&request)
+	} else {
+		lines, err = fileHandler.ScanLines(body)
+	}
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeServeJSON(w, http.StatusRequestEntityTooLarge, serveErrorResponse{Error: "ERROR: request body is too large"})
+		} else {
+			writeServeJSON(w, http.StatusBadRequest, serveErrorResponse{Error: "ERROR: invalid request, " + err.Error()})
+		}
+		return request, nil, nil, false
+	}
+
+	if lines == nil {
+		farmContent, _ := fileHandler.ScanLines(strings.NewReader(request.Farm))
+		movesContent, _ := fileHandler.ScanLines(strings.NewReader(request.Moves))
+		return request, farmContent, movesContent, true
+	}
+	// The moves come after the first empty line, like the output of lem-in
+	for i, line := range lines {
+		if line == "" {
+			return request, lines[:i], lines[i+1:], true
+		}
+	}
+	return request, lines, nil, true
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
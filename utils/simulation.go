@@ -0,0 +1,169 @@
+package utils
+
+import "slices"
+
+// Simulation answers questions about the moves SimulateAnts makes without
+// replaying them. Ants follow their path one room per turn, so an ant is known
+// from its path and the turn it leaves the start room: the k-th ant of a path
+// enters the first room of it in turn k+1. Turns count from 1, turn 0 is
+// before the first move.
+type Simulation struct {
+	paths     [][]string // rooms of each path without the start room
+	start     string
+	end       string
+	antPath   []int   // path of ant id-1
+	departure []int   // turns ant id-1 waits in the start room
+	pathAnts  [][]int // ids of the ants of each path in order of departure
+	roomPath  map[string][2]int
+	turns     int
+}
+
+// NewSimulation builds a Simulation from the queue MakeAntsQueue returns and
+// the paths it was given
+func NewSimulation(solutions []Solution, pathsNames [][]string, start, end Room) *Simulation {
+	simulation := &Simulation{
+		paths:    pathsNames,
+		start:    start.Name,
+		end:      end.Name,
+		pathAnts: make([][]int, len(pathsNames)),
+		roomPath: make(map[string][2]int),
+	}
+	for pathIndex, path := range pathsNames {
+		for roomIndex, roomName := range path {
+			if roomName != end.Name {
+				simulation.roomPath[roomName] = [2]int{pathIndex, roomIndex}
+			}
+		}
+	}
+
+	numberOfAnts := 0
+	for _, solution := range solutions {
+		numberOfAnts += len(solution.Ants)
+	}
+	simulation.antPath = make([]int, numberOfAnts)
+	simulation.departure = make([]int, numberOfAnts)
+	for _, solution := range solutions {
+		for departure, ant := range solution.Ants {
+			simulation.antPath[ant.Id-1] = solution.PathIndex
+			simulation.departure[ant.Id-1] = departure
+			simulation.pathAnts[solution.PathIndex] = append(simulation.pathAnts[solution.PathIndex], ant.Id)
+		}
+	}
+	// The last ant of a path leaves last and arrives last
+	for pathIndex, ants := range simulation.pathAnts {
+		if len(ants) > 0 {
+			simulation.turns = max(simulation.turns, len(ants)-1+len(pathsNames[pathIndex]))
+		}
+	}
+	return simulation
+}
+
+// Turns is the number of turns until the last ant reaches the end room
+func (s *Simulation) Turns() int {
+	return s.turns
+}
+
+// PositionAt returns the room the ant is in at the end of turn, or "" for an
+// unknown ant
+func (s *Simulation) PositionAt(antId, turn int) string {
+	if antId < 1 || antId > len(s.antPath) {
+		return ""
+	}
+	path := s.paths[s.antPath[antId-1]]
+	roomIndex := turn - s.departure[antId-1] - 1
+	switch {
+	case roomIndex < 0:
+		return s.start
+	case roomIndex >= len(path)-1:
+		return s.end
+	}
+	return path[roomIndex]
+}
+
+// ArrivalTurn returns the turn the ant reaches the end room, or -1 for an
+// unknown ant
+func (s *Simulation) ArrivalTurn(antId int) int {
+	if antId < 1 || antId > len(s.antPath) {
+		return -1
+	}
+	return s.departure[antId-1] + len(s.paths[s.antPath[antId-1]])
+}
+
+// OccupantsAt returns the ants in room at the end of turn in increasing
+// order. A room of a path holds at most one ant, the start and end rooms can
+// hold them all.
+func (s *Simulation) OccupantsAt(roomName string, turn int) []int {
+	var occupants []int
+	switch roomName {
+	case s.start, s.end:
+		// The ants of a path leave one per turn from turn 1 and arrive one per
+		// turn len(path) turns later
+		for pathIndex, ants := range s.pathAnts {
+			if roomName == s.start {
+				left := min(max(turn, 0), len(ants))
+				occupants = append(occupants, ants[left:]...)
+			} else {
+				arrived := min(max(turn-len(s.paths[pathIndex])+1, 0), len(ants))
+				occupants = append(occupants, ants[:arrived]...)
+			}
+		}
+		slices.Sort(occupants)
+		return occupants
+	}
+
+	position, exists := s.roomPath[roomName]
+	if !exists {
+		return nil
+	}
+	ants := s.pathAnts[position[0]]
+	if departure := turn - position[1] - 1; departure >= 0 && departure < len(ants) {
+		occupants = append(occupants, ants[departure])
+	}
+	return occupants
+}
+
+// AntTrace is the route of one ant, Path counts from 1
+type AntTrace struct {
+	Ant       int         `json:"ant"`
+	Path      int         `json:"path"`
+	Departure int         `json:"departure"` // turn the ant leaves the start room
+	Rooms     []RoomVisit `json:"rooms"`
+	Arrival   int         `json:"arrival"`
+}
+
+// RoomVisit is a room an ant entered and the turn it did
+type RoomVisit struct {
+	Room string `json:"room"`
+	Turn int    `json:"turn"`
+}
+
+// Trace returns the route of an ant and false for an unknown ant
+func (s *Simulation) Trace(antId int) (AntTrace, bool) {
+	if antId < 1 || antId > len(s.antPath) {
+		return AntTrace{}, false
+	}
+	pathIndex, departure := s.antPath[antId-1], s.departure[antId-1]
+	trace := AntTrace{Ant: antId, Path: pathIndex + 1, Departure: departure + 1, Arrival: s.ArrivalTurn(antId)}
+	for roomIndex, roomName := range s.paths[pathIndex] {
+		trace.Rooms = append(trace.Rooms, RoomVisit{Room: roomName, Turn: departure + roomIndex + 1})
+	}
+	return trace, true
+}
+
+// TunnelUsage returns the tunnels crossed during turn as from and to rooms,
+// path by path
+func (s *Simulation) TunnelUsage(turn int) [][2]string {
+	var tunnels [][2]string
+	for pathIndex, path := range s.paths {
+		ants := len(s.pathAnts[pathIndex])
+		// During turn t the ant that left at departure d enters room t-d-1
+		for roomIndex := max(0, turn-ants); roomIndex < len(path) && roomIndex <= turn-1; roomIndex++ {
+			from := s.start
+			if roomIndex > 0 {
+				from = path[roomIndex-1]
+			}
+			tunnels = append(tunnels, [2]string{from, path[roomIndex]})
+		}
+	}
+	return tunnels
+}
@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+)
+
+// Result is the answer of a solver. Paths is empty for solvers that do not
+// send the ants along fixed paths.
+type Result struct {
+	Paths       [][]string
+	Turns       [][]Move
+	PrunedRooms int
+	// Alternatives are the next best groups of paths, see WithAlternatives
+	Alternatives []Result
+	// AlternativesCut is set when ctx was done before every group was ranked
+	AlternativesCut bool
+}
+
+// Solver finds the moves that bring all the ants of a farm to the end room.
+// When ctx is done a solver returns the best solution it found so far.
+type Solver func(ctx context.Context, farm Farm, options ...SolveOption) (Result, error)
+
+// SolveConfig holds the settings of a solver, set with SolveOption functions
+type SolveConfig struct {
+	Jobs int
+	// PathLimit is how many of the shortest paths are grouped, 0 means every
+	// path and AdaptivePaths lets AdaptivePathLimit choose
+	PathLimit int
+	// Seed shuffles the paths of the same length before the search when it is
+	// not 0, to try other ways of breaking ties
+	Seed int64
+	// Alternatives is how many other groups of paths SolvePaths returns
+	Alternatives int
+	// Objective decides between groups of paths as fast as each other
+	Objective Objective
+}
+
+const AdaptivePaths = -1
+
+type SolveOption func(*SolveConfig)
+
+// WithJobs makes the solver search with this many goroutines
+func WithJobs(jobs int) SolveOption {
+	return func(config *SolveConfig) {
+		config.Jobs = jobs
+	}
+}
+
+// WithPathLimit makes the solver group only the k shortest paths
+func WithPathLimit(k int) SolveOption {
+	return func(config *SolveConfig) {
+		config.PathLimit = k
+	}
+}
+
+// WithSeed makes the solver break ties between paths of the same length in a
+// random order given by seed instead of the order of ComparePaths
+func WithSeed(seed int64) SolveOption {
+	return func(config *SolveConfig) {
+		config.Seed = seed
+	}
+}
+
+// WithObjective makes the solver prefer, among the fastest groups of paths, the
+// best one on the rest of objective
+func WithObjective(objective Objective) SolveOption {
+	return func(config *SolveConfig) {
+		config.Objective = objective
+	}
+}
+
+func makeSolveConfig(options []SolveOption) SolveConfig {
+	config := SolveConfig{Jobs: 1}
+	for _, option := range options {
+		option(&config)
+	}
+	return config
+}
+
+var solvers = map[string]Solver{}
+
+// RegisterSolver makes a solver available by name, e.g. for lem-in bench
+func RegisterSolver(name string, solver Solver) {
+	if _, exists := solvers[name]; exists {
+		panic("lem-in: solver " + name + " registered twice")
+	}
+	solvers[name] = solver
+}
+
+// GetSolver returns the solver registered with name
+func GetSolver(name string) (Solver, error) {
+	solver, exists := solvers[name]
+	if !exists {
+		return nil, fmt.Errorf("ERROR: unknown solver %q", name)
+	}
+	return solver, nil
+}
+
+// SolverNames returns the names of the registered solvers in sorted order
+func SolverNames() []string {
+	var names []string
+	for name := range solvers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BenchSolverNames returns the solvers bench runs without --solvers, every
+// one but geometric whose turns are counted in tunnel lengths
+func BenchSolverNames() []string {
+	var names []string
+	for _, name := range SolverNames() {
+		if name != "geometric" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func init() {
+	RegisterSolver("paths", SolvePaths)
+	RegisterSolver("exact", SolveExact)
+	RegisterSolver("geometric", SolveGeometric)
+}
+
+// SolvePaths sends the ants along the best group of paths that do not share
+// rooms, this is the default solver.
+func SolvePaths(ctx context.Context, farm Farm, options ...SolveOption) (Result, error) {
+	config := makeSolveConfig(options)
+
+	// Step 0 and 1: Remove the rooms no path can go through, then extract all
+	// paths, or only the shortest ones
+	allPaths, prunedRooms, err := findSolverPaths(ctx, farm, config, KShortestPaths)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// Step 2 to 4: Find the best group of non-intersecting paths, with
+	// branch and bound instead of keeping every group
+	lengths := make([]int, len(allPaths))
+	for i, path := range allPaths {
+		lengths[i] = len(path)
+	}
+	bestPathGroupNames := SearchBestWeightedPathGroup(ctx, allPaths, lengths, farm.NumberOfAnts, config.Jobs, config.Objective)
+
+	// Step 5: Assign ants to group of paths named solution
+	solutions := MakeAntsQueue(bestPathGroupNames, farm.NumberOfAnts)
+
+	// Step 6: Move ants in solution
+	turns := SimulateAnts(solutions, bestPathGroupNames, farm.Rooms, farm.NumberOfAnts, farm.End)
+
+	result := Result{Paths: bestPathGroupNames, Turns: turns, PrunedRooms: prunedRooms}
+	if config.Alternatives > 0 {
+		result.Alternatives, result.AlternativesCut = findAlternatives(ctx, farm, allPaths, bestPathGroupNames, config.Alternatives, config.Objective)
+	}
+	return result, nil
+}
+
+// findSolverPaths prunes the farm and returns the paths the group search
+// chooses from, in the order of ComparePaths, and the number of rooms pruned.
+// A path limit takes the shortest paths of kShortestPaths.
+func findSolverPaths(ctx context.Context, farm Farm, config SolveConfig, kShortestPaths func(context.Context, Graph, Room, Room, []Room, int) ([][]Room, error)) ([][]Room, int, error) {
+	graph, prunedRooms, err := PruneGraph(farm.Graph, farm.Rooms, farm.Start, farm.End)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var allPaths [][]Room
+	switch {
+	case config.PathLimit == AdaptivePaths:
+		allPaths, err = kShortestPaths(ctx, graph, farm.Start, farm.End, farm.Rooms, AdaptivePathLimit(farm))
+	case config.PathLimit > 0:
+		allPaths, err = kShortestPaths(ctx, graph, farm.Start, farm.End, farm.Rooms, config.PathLimit)
+	default:
+		allPaths, err = FindAllPaths(ctx, graph, farm.Start, farm.End, farm.Rooms)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sort.Sort(PathSlice(allPaths))
+	if config.Seed != 0 {
+		random := rand.New(rand.NewSource(config.Seed))
+		random.Shuffle(len(allPaths), func(i, j int) { allPaths[i], allPaths[j] = allPaths[j], allPaths[i] })
+		sort.SliceStable(allPaths, func(i, j int) bool { return len(allPaths[i]) < len(allPaths[j]) })
+	}
+	return allPaths, prunedRooms, nil
+}
+
+// SolveExact uses the time-expanded scheduler, it is only fast enough for
+// small and medium farms.
+func SolveExact(ctx context.Context, farm Farm, options ...SolveOption) (Result, error) {
+	graph, prunedRooms, err := PruneGraph(farm.Graph, farm.Rooms, farm.Start, farm.End)
+	if err != nil {
+		return Result{}, err
+	}
+	turns, err := scheduleTimeExpanded(ctx, graph, farm.Rooms, farm.Start, farm.End, farm.NumberOfAnts, 0)
+	if err != nil {
+		return Result{}, err
+	}
+	if turns == nil && ctx.Err() != nil {
+		return Result{}, ctx.Err()
+	}
+	if turns == nil {
+		return Result{}, errors.New("ERROR: invalid data format, no path found")
+	}
+	return Result{Turns: turns, PrunedRooms: prunedRooms}, nil
+}
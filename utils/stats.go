@@ -0,0 +1,98 @@
+package utils
+
+import "fmt"
+
+// Stats tells how good a solution is compared to what is provably possible,
+// with the values the objective compares solutions on
+type Stats struct {
+	Turns       int    `json:"turns"`
+	Moves       int    `json:"moves"`
+	Arrivals    int    `json:"arrivals"` // sum of the turns the ants arrive at
+	RoomsUsed   int    `json:"rooms_used"`
+	LowerBound  int    `json:"lower_bound"`
+	Gap         int    `json:"gap"`
+	PrunedRooms int    `json:"pruned_rooms"`
+	Objective   string `json:"objective,omitempty"`
+}
+
+func MakeStats(turns [][]Move, end Room, lowerBound, prunedRooms int) Stats {
+	stats := Stats{Turns: len(turns), LowerBound: lowerBound, Gap: len(turns) - lowerBound, PrunedRooms: prunedRooms}
+	roomsUsed := make(map[string]bool)
+	for turnIndex, moves := range turns {
+		stats.Moves += len(moves)
+		for _, move := range moves {
+			switch {
+			case move.InTransit():
+			case move.RoomName == end.Name:
+				stats.Arrivals += turnIndex + 1
+			default:
+				roomsUsed[move.RoomName] = true
+			}
+		}
+	}
+	stats.RoomsUsed = len(roomsUsed)
+	return stats
+}
+
+func (s Stats) String() string {
+	line := fmt.Sprintf("turns=%d, moves=%d, arrivals=%d, rooms_used=%d, lower_bound=%d, gap=%d, pruned_rooms=%d",
+		s.Turns, s.Moves, s.Arrivals, s.RoomsUsed, s.LowerBound, s.Gap, s.PrunedRooms)
+	if s.Objective != "" {
+		line += ", objective=" + s.Objective
+	}
+	return line
+}
+
+// LowerBound returns a number of turns no solution can beat, or -1 when end
+// can not be reached. The last ant can not cross a minimum vertex cut of c
+// rooms before ceil(ants/c) turns have passed and still has to walk at least
+// the shortest path, so turns >= shortest + ceil(ants/c) - 1.
+func LowerBound(graph Graph, rooms []Room, start, end Room, numberOfAnts int) int {
+	distance := shortestDistance(graph, start.Name, end.Name)
+	if distance == -1 {
+		return -1
+	}
+	cut := MaxDisjointPaths(graph, rooms, start, end, numberOfAnts)
+	return distance + (numberOfAnts+cut-1)/cut - 1
+}
+
+// MaxDisjointPaths returns the number of paths from start to end that do not
+// share any room, which is also the size of the minimum vertex cut. Counting
+// stops at limit.
+func MaxDisjointPaths(graph Graph, rooms []Room, start, end Room, limit int) int {
+	network, source, sink := newRoomNetwork(graph, rooms, start, end, limit)
+	return network.maxFlow(source, sink, limit)
+}
+
+// newRoomNetwork builds a flow network where every room is split in an in and
+// an out node joined by an edge of capacity one, so each room is used by one
+// path only. Start and end may be used by up to limit paths, a tunnel from
+// start to end by one.
+func newRoomNetwork(graph Graph, rooms []Room, start, end Room, limit int) (*flowNetwork, int, int) {
+	network := newFlowNetwork(2 * len(rooms))
+	roomIndex := make(map[string]int, len(rooms))
+	for i, room := range rooms {
+		roomIndex[room.Name] = i
+	}
+	for i, room := range rooms {
+		capacity := 1
+		if room.Name == start.Name || room.Name == end.Name {
+			capacity = limit
+		}
+		network.addEdge(2*i, 2*i+1, capacity)
+		for _, neighborName := range graph.Edges[room.Name] {
+			neighborIndex, exists := roomIndex[neighborName]
+			if !exists {
+				continue
+			}
+			// Only rooms limit the flow, so its minimum cut is made of rooms,
+			// apart from tunnels going straight from start to end
+			tunnelCapacity := limit
+			if room.Name == start.Name && neighborName == end.Name {
+				tunnelCapacity = 1
+			}
+			network.addEdge(2*i+1, 2*neighborIndex, tunnelCapacity)
+		}
+	}
+	return network, 2 * roomIndex[start.Name], 2*roomIndex[end.Name] + 1
+}
@@ -0,0 +1,44 @@
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+// stopChecker tells the search loops when to give up. ctx.Err takes a lock so
+// the context is only looked at every checkInterval calls.
+type stopChecker struct {
+	ctx   context.Context
+	calls int
+	done  bool
+}
+
+const checkInterval = 1024
+
+func newStopChecker(ctx context.Context) *stopChecker {
+	return &stopChecker{ctx: ctx}
+}
+
+// stopped is false forever on a nil stopChecker
+func (s *stopChecker) stopped() bool {
+	if s == nil {
+		return false
+	}
+	if s.done {
+		return true
+	}
+	s.calls++
+	if s.calls%checkInterval == 0 && s.ctx.Err() != nil {
+		s.done = true
+	}
+	return s.done
+}
+
+// withTimeout returns a context ending after timeout, or one that never ends
+// when timeout is zero.
+func withTimeout(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
@@ -12,6 +12,7 @@ type Room struct {
 type Tunnel struct {
 	FromRoom Room
 	ToRoom   Room
+	Directed bool // Only FromRoom -> ToRoom can be used (a>b)
 }
 type Ant struct {
 	Id               int
@@ -20,6 +21,17 @@ type Ant struct {
 	HasReachedTheEnd bool
 }
 
+// Move is an ant entering a room during a turn. In geometric mode an ant
+// still walking a long tunnel towards RoomName has Steps set to the length of
+// the tunnel and Step to the turns already walked.
+type Move struct {
+	AntId    int
+	RoomName string
+	From     string
+	Step     int
+	Steps    int
+}
+
 type Solution struct {
 	PathIndex int
 	Ants      []Ant
@@ -36,3 +48,8 @@ func (g *Graph) AddEdge(from, to string) {
 	g.Edges[from] = append(g.Edges[from], to)
 	g.Edges[to] = append(g.Edges[to], from) // For undirected flow
 }
+
+// Add a one-way edge to the graph
+func (g *Graph) AddArc(from, to string) {
+	g.Edges[from] = append(g.Edges[from], to)
+}
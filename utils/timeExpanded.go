@@ -0,0 +1,282 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// maxTimeExpandedNodes is the most nodes a time-expanded network is built
+// with, about 100 MB of nodes before the edges
+const maxTimeExpandedNodes = 1 << 22
+
+// timeExpandedTunnel is a tunnel of the graph, two-way unless directed.
+type timeExpandedTunnel struct {
+	from, to int
+	directed bool
+}
+
+// ScheduleTimeExpanded finds a schedule with the fewest possible turns by
+// running a max flow over the time-expanded network (room x turn). Unlike the
+// path group solver ants may share rooms of different paths and wait inside the
+// farm. It is meant for small and medium farms, the network grows with
+// rooms * turns. Schedules longer than maxTurns are not searched, a maxTurns of
+// zero or less means the length of the shortest path plus one turn per ant.
+// It returns nil when there is no schedule within maxTurns. When ctx is done
+// the shortest schedule found so far is returned, nil if there is none yet.
+func ScheduleTimeExpanded(ctx context.Context, graph Graph, rooms []Room, start, end Room, numberOfAnts, maxTurns int) [][]Move {
+	turns, _ := scheduleTimeExpanded(ctx, graph, rooms, start, end, numberOfAnts, maxTurns)
+	return turns
+}
+
+// scheduleTimeExpanded is ScheduleTimeExpanded returning an error instead of
+// building a network of more than maxTimeExpandedNodes nodes
+func scheduleTimeExpanded(ctx context.Context, graph Graph, rooms []Room, start, end Room, numberOfAnts, maxTurns int) ([][]Move, error) {
+	startIndex := FindRoom(start.Name, rooms)
+	endIndex := FindRoom(end.Name, rooms)
+	if startIndex == -1 || endIndex == -1 || numberOfAnts < 1 {
+		return nil, nil
+	}
+
+	distance := shortestDistance(graph, start.Name, end.Name)
+	if distance == -1 {
+		return nil, nil
+	}
+	if maxTurns <= 0 {
+		maxTurns = distance + numberOfAnts - 1
+	}
+	if maxTurns < distance {
+		return nil, nil
+	}
+
+	if nodes := 2 * len(rooms) * (maxTurns + 1); nodes > maxTimeExpandedNodes {
+		return nil, fmt.Errorf("ERROR: the time-expanded network of %d rooms over %d turns is too large", len(rooms), maxTurns)
+	}
+
+	tunnels := timeExpandedTunnels(graph, rooms)
+
+	// Binary search the smallest horizon that lets every ant arrive, a network
+	// whose building or max flow is cut short by ctx counts as no schedule
+	stop := newStopChecker(ctx)
+	schedule := func(turns int) *timeExpandedNetwork {
+		network := newTimeExpandedNetwork(stop, rooms, tunnels, startIndex, endIndex, numberOfAnts, turns)
+		if network == nil || !network.solve() {
+			return nil
+		}
+		return network
+	}
+	low, high := distance, maxTurns
+	best := schedule(high)
+	if best == nil {
+		return nil, nil
+	}
+	for low < high && !stop.stopped() {
+		middle := (low + high) / 2
+		if network := schedule(middle); network != nil {
+			high = middle
+			best = network
+		} else {
+			low = middle + 1
+		}
+	}
+	return best.moves(rooms), nil
+}
+
+// shortestDistance returns the number of tunnels on the shortest path from
+// start to end, or -1 when end can not be reached.
+func shortestDistance(graph Graph, start, end string) int {
+	distance := map[string]int{start: 0}
+	queue := []string{start}
+	for len(queue) > 0 {
+		roomName := queue[0]
+		queue = queue[1:]
+		if roomName == end {
+			return distance[roomName]
+		}
+		for _, neighborName := range graph.Edges[roomName] {
+			if _, seen := distance[neighborName]; !seen {
+				distance[neighborName] = distance[roomName] + 1
+				queue = append(queue, neighborName)
+			}
+		}
+	}
+	return -1
+}
+
+// timeExpandedTunnels rebuilds the tunnels from the adjacency list, an edge
+// listed in both directions is a two-way tunnel.
+func timeExpandedTunnels(graph Graph, rooms []Room) []timeExpandedTunnel {
+	arcs := make(map[[2]int]int)
+	var order [][2]int
+	for _, room := range rooms {
+		from := FindRoom(room.Name, rooms)
+		for _, neighborName := range graph.Edges[room.Name] {
+			arc := [2]int{from, FindRoom(neighborName, rooms)}
+			if arc[1] == -1 {
+				continue
+			}
+			if arcs[arc] == 0 {
+				order = append(order, arc)
+			}
+			arcs[arc]++
+		}
+	}
+
+	var tunnels []timeExpandedTunnel
+	for _, arc := range order {
+		reverse := [2]int{arc[1], arc[0]}
+		for arcs[arc] > 0 {
+			arcs[arc]--
+			if arcs[reverse] > 0 {
+				arcs[reverse]--
+				tunnels = append(tunnels, timeExpandedTunnel{from: arc[0], to: arc[1]})
+			} else {
+				tunnels = append(tunnels, timeExpandedTunnel{from: arc[0], to: arc[1], directed: true})
+			}
+		}
+	}
+	return tunnels
+}
+
+// timeExpandedNetwork has an in and an out node for every room and turn. The
+// edge between them is the capacity of the room, start and end hold any number
+// of ants. Two-way tunnels go through a node of capacity one so they can only
+// be used once per turn whatever the direction.
+type timeExpandedNetwork struct {
+	flow         *flowNetwork
+	numberOfAnts int
+	turns        int
+	roomCount    int
+	source, sink int
+	start, end   int
+}
+
+// newTimeExpandedNetwork returns nil when stop says so before it is built
+func newTimeExpandedNetwork(stop *stopChecker, rooms []Room, tunnels []timeExpandedTunnel, start, end, numberOfAnts, turns int) *timeExpandedNetwork {
+	network := &timeExpandedNetwork{
+		numberOfAnts: numberOfAnts,
+		turns:        turns,
+		roomCount:    len(rooms),
+		start:        start,
+		end:          end,
+	}
+	network.flow = newFlowNetwork(2 * len(rooms) * (turns + 1))
+	network.flow.stop = stop
+	network.source = network.in(start, 0)
+	network.sink = network.flow.addNode()
+
+	for turn := 0; turn <= turns; turn++ {
+		for room := range rooms {
+			if stop.stopped() {
+				return nil
+			}
+			capacity := 1
+			if room == start || room == end {
+				capacity = numberOfAnts
+			}
+			if room == end {
+				if turn > 0 {
+					network.flow.addEdge(network.in(room, turn), network.sink, numberOfAnts)
+				}
+				continue
+			}
+			network.flow.addEdge(network.in(room, turn), network.out(room, turn), capacity)
+			if turn < turns {
+				// Waiting in the room for the next turn
+				network.flow.addEdge(network.out(room, turn), network.in(room, turn+1), capacity)
+			}
+		}
+		if turn == turns {
+			break
+		}
+		for _, tunnel := range tunnels {
+			if tunnel.directed {
+				network.addMove(tunnel.from, tunnel.to, turn)
+				continue
+			}
+			gadgetIn := network.flow.addNode()
+			gadgetOut := network.flow.addNode()
+			network.flow.addEdge(gadgetIn, gadgetOut, 1)
+			for _, room := range []int{tunnel.from, tunnel.to} {
+				if room != end {
+					network.flow.addEdge(network.out(room, turn), gadgetIn, 1)
+				}
+				if room != start {
+					network.flow.addEdge(gadgetOut, network.in(room, turn+1), 1)
+				}
+			}
+		}
+	}
+	return network
+}
+
+func (n *timeExpandedNetwork) in(room, turn int) int {
+	return 2 * (turn*n.roomCount + room)
+}
+
+func (n *timeExpandedNetwork) out(room, turn int) int {
+	return n.in(room, turn) + 1
+}
+
+func (n *timeExpandedNetwork) addMove(from, to, turn int) {
+	if from == n.end || to == n.start {
+		return
+	}
+	n.flow.addEdge(n.out(from, turn), n.in(to, turn+1), 1)
+}
+
+func (n *timeExpandedNetwork) solve() bool {
+	return n.flow.maxFlow(n.source, n.sink, n.numberOfAnts) == n.numberOfAnts
+}
+
+// moves splits the flow into one walk per ant and turns the walks into the
+// moves of each turn. Ants are numbered in the order they leave the start.
+func (n *timeExpandedNetwork) moves(rooms []Room) [][]Move {
+	var walks [][]int
+	for ant := 0; ant < n.numberOfAnts; ant++ {
+		walk := make([]int, n.turns+1)
+		for turn := range walk {
+			walk[turn] = -1
+		}
+		node := n.source
+		for node != n.sink {
+			if node < 2*n.roomCount*(n.turns+1) && node%2 == 0 {
+				room := (node / 2) % n.roomCount
+				turn := (node / 2) / n.roomCount
+				walk[turn] = room
+			}
+			for index := range n.flow.adj[node] {
+				if n.flow.flow(node, index) > 0 {
+					n.flow.adj[node][index].cap++
+					node = n.flow.adj[node][index].to
+					break
+				}
+			}
+		}
+		walks = append(walks, walk)
+	}
+
+	departure := func(walk []int) int {
+		for turn, room := range walk {
+			if room != n.start {
+				return turn
+			}
+		}
+		return len(walk)
+	}
+	sort.SliceStable(walks, func(i, j int) bool {
+		return departure(walks[i]) < departure(walks[j])
+	})
+
+	var turns [][]Move
+	for turn := 1; turn <= n.turns; turn++ {
+		var moves []Move
+		for antIndex, walk := range walks {
+			if walk[turn] != -1 && walk[turn] != walk[turn-1] {
+				moves = append(moves, Move{AntId: antIndex + 1, RoomName: rooms[walk[turn]].Name})
+			}
+		}
+		turns = append(turns, moves)
+	}
+	return turns
+}
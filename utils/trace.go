@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseAntIds reads the ants given to --trace-ant, like 17, 3-5 or 1,4,10-12,
+// as ranges of the first and the last ant. Ranges are only expanded by
+// ExpandAntIds, once the number of ants is known.
+func ParseAntIds(value string) ([][2]int, error) {
+	var antRanges [][2]int
+	for _, part := range strings.Split(value, ",") {
+		first, last, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		from, err := strconv.Atoi(first)
+		to := from
+		if err == nil && isRange {
+			to, err = strconv.Atoi(last)
+		}
+		if err != nil || from < 1 || to < from {
+			return nil, errors.New("ERROR: invalid --trace-ant value " + value)
+		}
+		antRanges = append(antRanges, [2]int{from, to})
+	}
+	return antRanges, nil
+}
+
+// ExpandAntIds returns every ant of the ranges of ParseAntIds, or an error
+// naming the first ant past numberOfAnts
+func ExpandAntIds(antRanges [][2]int, numberOfAnts int) ([]int, error) {
+	var antIds []int
+	for _, antRange := range antRanges {
+		if antRange[1] > numberOfAnts {
+			return nil, fmt.Errorf("ERROR: invalid --trace-ant value, there is no ant L%d", max(antRange[0], numberOfAnts+1))
+		}
+		for antId := antRange[0]; antId <= antRange[1]; antId++ {
+			antIds = append(antIds, antId)
+		}
+	}
+	return antIds, nil
+}
+
+// TraceAnts returns the route of every ant of antIds sent along paths by
+// MakeAntsQueue
+func TraceAnts(farm Farm, paths [][]string, antIds []int) ([]AntTrace, error) {
+	simulation := NewSimulation(MakeAntsQueue(paths, farm.NumberOfAnts), paths, farm.Start, farm.End)
+	var traces []AntTrace
+	for _, antId := range antIds {
+		trace, found := simulation.Trace(antId)
+		if !found {
+			return nil, fmt.Errorf("ERROR: invalid --trace-ant value, there is no ant L%d", antId)
+		}
+		traces = append(traces, trace)
+	}
+	return traces, nil
+}
+
+func PrintTraces(traces []AntTrace, numberOfPaths int) {
+	for _, trace := range traces {
+		fmt.Printf("trace L%d: path %d of %d, leaves the start room in turn %d, arrives in turn %d\n",
+			trace.Ant, trace.Path, numberOfPaths, trace.Departure, trace.Arrival)
+		for _, visit := range trace.Rooms {
+			fmt.Printf("   turn %d: %s\n", visit.Turn, visit.Room)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// UsageReport tells how much each room and tunnel of a farm was used by a
+// solution, and how many ants reached the end room in each turn
+type UsageReport struct {
+	Rooms   []RoomUsage      `json:"rooms"`
+	Tunnels []TunnelTraffic  `json:"tunnels"`
+	Turns   []TurnThroughput `json:"turns"`
+}
+
+// RoomUsage counts the ants that entered a room and the turns it ended with
+// an ant inside
+type RoomUsage struct {
+	Room          string `json:"room"`
+	Ants          int    `json:"ants"`
+	TurnsOccupied int    `json:"turns_occupied"`
+}
+
+// TunnelTraffic counts the ants that crossed a tunnel, both ways together
+type TunnelTraffic struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Ants int    `json:"ants"`
+}
+
+// TurnThroughput counts the ants reaching the end room in a turn, and all the
+// ants there at the end of it
+type TurnThroughput struct {
+	Turn     int `json:"turn"`
+	Arrivals int `json:"arrivals"`
+	Total    int `json:"total"`
+}
+
+// MakeUsageReport counts the use of every room and tunnel by the ants
+// MakeAntsQueue sends along paths, from the ants of each path: they all go
+// through every room and tunnel of it, one turn in each room, and arrive one
+// per turn. Rooms and tunnels are listed in the order of the file, the unused
+// ones too, without the start and end rooms.
+func MakeUsageReport(farm Farm, paths [][]string) UsageReport {
+	var report UsageReport
+	roomIndex := make(map[string]int)
+	for _, room := range farm.Rooms {
+		if room.Name == farm.Start.Name || room.Name == farm.End.Name {
+			continue
+		}
+		roomIndex[room.Name] = len(report.Rooms)
+		report.Rooms = append(report.Rooms, RoomUsage{Room: room.Name})
+	}
+	tunnelIndex := make(map[[2]string]int)
+	for _, tunnel := range farm.Tunnels {
+		pair := roomPair(tunnel.FromRoom.Name, tunnel.ToRoom.Name)
+		if _, exists := tunnelIndex[pair]; !exists {
+			tunnelIndex[pair] = len(report.Tunnels)
+			report.Tunnels = append(report.Tunnels, TunnelTraffic{From: tunnel.FromRoom.Name, To: tunnel.ToRoom.Name})
+		}
+	}
+
+	simulation := NewSimulation(MakeAntsQueue(paths, farm.NumberOfAnts), paths, farm.Start, farm.End)
+	for pathIndex, path := range paths {
+		ants := len(simulation.pathAnts[pathIndex])
+		from := farm.Start.Name
+		for _, roomName := range path {
+			if i, exists := tunnelIndex[roomPair(from, roomName)]; exists {
+				report.Tunnels[i].Ants += ants
+			}
+			if i, exists := roomIndex[roomName]; exists {
+				report.Rooms[i].Ants += ants
+				report.Rooms[i].TurnsOccupied += ants
+			}
+			from = roomName
+		}
+	}
+
+	// The k-th ant of a path arrives in turn k+len(path)
+	total := 0
+	for turn := 1; turn <= simulation.Turns(); turn++ {
+		arrivals := 0
+		for pathIndex, path := range paths {
+			if ants := len(simulation.pathAnts[pathIndex]); turn >= len(path) && turn < len(path)+ants {
+				arrivals++
+			}
+		}
+		total += arrivals
+		report.Turns = append(report.Turns, TurnThroughput{Turn: turn, Arrivals: arrivals, Total: total})
+	}
+	return report
+}
+
+func PrintUsageReport(report UsageReport) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ROOM\tANTS\tTURNS OCCUPIED")
+	for _, room := range report.Rooms {
+		fmt.Fprintf(writer, "%s\t%d\t%d\n", room.Room, room.Ants, room.TurnsOccupied)
+	}
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "TUNNEL\tANTS")
+	for _, tunnel := range report.Tunnels {
+		fmt.Fprintf(writer, "%s-%s\t%d\n", tunnel.From, tunnel.To, tunnel.Ants)
+	}
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "TURN\tARRIVALS\tTOTAL")
+	for _, turn := range report.Turns {
+		fmt.Fprintf(writer, "%d\t%d\t%d\n", turn.Turn, turn.Arrivals, turn.Total)
+	}
+	writer.Flush()
+}
+
+// WriteUsageReport writes the report as JSON when fileName ends with .json and
+// as CSV otherwise, one row per room, tunnel and turn
+func WriteUsageReport(fileName string, report UsageReport) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if strings.HasSuffix(fileName, ".json") {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
+
+	writer := csv.NewWriter(file)
+	writer.Write([]string{"kind", "name", "ants", "turns_occupied", "arrivals", "total"})
+	for _, room := range report.Rooms {
+		writer.Write([]string{"room", room.Room, strconv.Itoa(room.Ants), strconv.Itoa(room.TurnsOccupied), "", ""})
+	}
+	for _, tunnel := range report.Tunnels {
+		writer.Write([]string{"tunnel", tunnel.From + "-" + tunnel.To, strconv.Itoa(tunnel.Ants), "", "", ""})
+	}
+	for _, turn := range report.Turns {
+		writer.Write([]string{"turn", strconv.Itoa(turn.Turn), "", "", strconv.Itoa(turn.Arrivals), strconv.Itoa(turn.Total)})
+	}
+	writer.Flush()
+	return writer.Error()
+}
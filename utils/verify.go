@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseTranscript reads the moves printed by PrintMoves, one turn per line.
+// The "turn N:" prefix and the highlighting of the end room are optional, ants
+// inside a tunnel are written L1-from>to:1/3.
+func ParseTranscript(lines []string) ([][]Move, error) {
+	var turns [][]Move
+	for lineIndex, line := range lines {
+		line = strings.ReplaceAll(strings.ReplaceAll(line, "\033[43m", ""), "\033[0m", "")
+		if strings.HasPrefix(line, "turn ") {
+			colon := strings.Index(line, ":")
+			if colon == -1 {
+				return nil, fmt.Errorf("ERROR: invalid transcript, line %d: missing ':' after the turn number", lineIndex+1)
+			}
+			line = line[colon+1:]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		var moves []Move
+		for _, field := range fields {
+			dash := strings.Index(field, "-")
+			if !strings.HasPrefix(field, "L") || dash == -1 {
+				return nil, fmt.Errorf("ERROR: invalid transcript, line %d: invalid move %q", lineIndex+1, field)
+			}
+			antId, err := strconv.Atoi(field[1:dash])
+			if err != nil || antId < 1 || dash == len(field)-1 {
+				return nil, fmt.Errorf("ERROR: invalid transcript, line %d: invalid move %q", lineIndex+1, field)
+			}
+			move := Move{AntId: antId, RoomName: field[dash+1:]}
+			if from, rest, found := strings.Cut(move.RoomName, ">"); found {
+				to, progress, _ := strings.Cut(rest, ":")
+				step, steps, _ := strings.Cut(progress, "/")
+				move.From, move.RoomName = from, to
+				move.Step, _ = strconv.Atoi(step)
+				move.Steps, _ = strconv.Atoi(steps)
+				if from == "" || to == "" || move.Step < 1 || move.Step >= move.Steps {
+					return nil, fmt.Errorf("ERROR: invalid transcript, line %d: invalid move %q", lineIndex+1, field)
+				}
+			}
+			moves = append(moves, move)
+		}
+		turns = append(turns, moves)
+	}
+	return turns, nil
+}
+
+// VerifyMoves checks that the moves follow the rules: every ant moves at most
+// once a turn through an existing tunnel, one-way tunnels are only taken in
+// their direction, each tunnel is used once a turn, rooms other than start and
+// end hold one ant at the end of every turn and all ants reach the end room.
+// Ants inside a tunnel must be in one leaving the room they are in, the
+// number of turns spent in the tunnel is not checked.
+func VerifyMoves(farm Farm, turns [][]Move) error {
+	position := make([]string, farm.NumberOfAnts+1)
+	for antId := range position {
+		position[antId] = farm.Start.Name
+	}
+	occupant := make(map[string]int)
+
+	// Two-way tunnels between two rooms, whatever their direction, and
+	// one-way tunnels by their direction
+	tunnels := make(map[[2]string]int)
+	arcs := make(map[[2]string]int)
+	for _, tunnel := range farm.Tunnels {
+		if tunnel.Directed {
+			arcs[[2]string{tunnel.FromRoom.Name, tunnel.ToRoom.Name}]++
+		} else {
+			tunnels[roomPair(tunnel.FromRoom.Name, tunnel.ToRoom.Name)]++
+		}
+	}
+
+	for turnIndex, moves := range turns {
+		turn := turnIndex + 1
+		moved := make(map[int]bool)
+		used := make(map[[2]string]int)
+		usedArcs := make(map[[2]string]int)
+		for _, move := range moves {
+			if move.AntId < 1 || move.AntId > farm.NumberOfAnts {
+				return moveError(turn, "unknown ant L%d", move.AntId)
+			}
+			if moved[move.AntId] {
+				return moveError(turn, "ant L%d moves twice", move.AntId)
+			}
+			moved[move.AntId] = true
+
+			from := position[move.AntId]
+			if from == farm.End.Name {
+				return moveError(turn, "ant L%d moves after reaching the end", move.AntId)
+			}
+			if move.InTransit() {
+				if move.From != from || !containsRoomName(farm.Graph.Edges[from], move.RoomName) {
+					return moveError(turn, "ant L%d can not be between %s and %s", move.AntId, move.From, move.RoomName)
+				}
+				if occupant[from] == move.AntId {
+					delete(occupant, from)
+				}
+				continue
+			}
+			if !containsRoomName(farm.Graph.Edges[from], move.RoomName) {
+				return moveError(turn, "ant L%d can not go from %s to %s", move.AntId, from, move.RoomName)
+			}
+			// A one-way tunnel is taken first, only a move in its direction
+			// can use it
+			arc := [2]string{from, move.RoomName}
+			pair := roomPair(from, move.RoomName)
+			if usedArcs[arc] < arcs[arc] {
+				usedArcs[arc]++
+			} else if used[pair] < tunnels[pair] {
+				used[pair]++
+			} else {
+				return moveError(turn, "tunnel %s-%s is used more than once", from, move.RoomName)
+			}
+
+			if occupant[from] == move.AntId {
+				delete(occupant, from)
+			}
+			position[move.AntId] = move.RoomName
+		}
+
+		// Rooms are checked once every ant of the turn has moved, an ant can
+		// enter a room another one leaves in the same turn
+		for _, move := range moves {
+			roomName := move.RoomName
+			if move.InTransit() || roomName == farm.Start.Name || roomName == farm.End.Name {
+				continue
+			}
+			if other, occupied := occupant[roomName]; occupied && other != move.AntId && position[other] == roomName {
+				return moveError(turn, "ants L%d and L%d are both in room %s", other, move.AntId, roomName)
+			}
+			occupant[roomName] = move.AntId
+		}
+	}
+
+	for antId := 1; antId <= farm.NumberOfAnts; antId++ {
+		if position[antId] != farm.End.Name {
+			return fmt.Errorf("ERROR: invalid moves, ant L%d does not reach the end room", antId)
+		}
+	}
+	return nil
+}
+
+func moveError(turn int, format string, args ...any) error {
+	return fmt.Errorf("ERROR: invalid moves, turn %d: %s", turn, fmt.Sprintf(format, args...))
+}
+
+func roomPair(a, b string) [2]string {
+	if b < a {
+		a, b = b, a
+	}
+	return [2]string{a, b}
+}